@@ -1,157 +1,108 @@
 package main
 
 import (
+	"flag"
 	"fmt"
 	"log"
-
-	"github.com/jroimartin/gocui"
-)
-
-var (
-	gridSize    = 10
-	grid        = [10][10]string{}
-	currentView = "menu"
-	lastClicked = ""
+	"os"
+	"path/filepath"
+	"runtime"
+	"time"
+
+	"github.com/eaglerock1337/gobat/pkg/board"
+	"github.com/eaglerock1337/gobat/pkg/player"
+	"github.com/eaglerock1337/gobat/pkg/record"
+	"github.com/eaglerock1337/gobat/pkg/sim"
 )
 
-func initGrid() {
-	for i := 0; i < gridSize; i++ {
-		for j := 0; j < gridSize; j++ {
-			grid[i][j] = fmt.Sprintf("%c%d", 'A'+i, j+1)
-		}
-	}
+// writeRecord writes the game record of an abandoned game to the given directory.
+func writeRecord(dir, engine, placement string, rules board.Ruleset, game sim.Game) error {
+	rec := record.New()
+	rec.SetRules(rules)
+	rec.Moves = game.Moves
+	rec.SetTag("Hunter", engine)
+	rec.SetTag("Placement", placement)
+	rec.SetTag("Seed", fmt.Sprint(game.Seed))
+	rec.SetTag("Result", fmt.Sprintf("Abandoned: %v", game.Err))
+
+	name := fmt.Sprintf("%s-%s-%d.gbr", engine, placement, game.Seed)
+	return rec.WriteFile(filepath.Join(dir, name))
 }
 
-func layout(g *gocui.Gui) error {
-	if currentView == "menu" {
-		return layoutMenu(g)
+func main() {
+	games := flag.Int("games", 1000, "number of games to simulate")
+	seed := flag.Int64("seed", time.Now().UnixNano(), "seed for placing the hidden fleets")
+	workers := flag.Int("workers", runtime.NumCPU(), "number of games to play concurrently")
+	width := flag.Int("bucket", 5, "width of each histogram bucket in turns")
+	placement := flag.String("placement", "random", "fleet placement strategy, or \"all\" to compare every strategy")
+	engine := flag.String("engine", "hunter", "shooting engine, or \"all\" to compare every engine")
+	rulesFlag := flag.String("rules", board.DefaultRuleset().Name, "built-in ruleset name, or the path to a JSON ruleset file")
+	size := flag.String("size", "", "board size as width by height (e.g. 8x8 or 12x12), overriding the rules")
+	records := flag.String("records", "", "directory to write a game record of every abandoned game")
+	verbose := flag.Bool("v", false, "print every abandoned game")
+	flag.Parse()
+
+	rules, err := board.LoadRuleset(*rulesFlag)
+	if err != nil {
+		log.Fatalf("unable to load the rules: %v", err)
 	}
-	return layoutGrid(g)
-}
-
-func layoutMenu(g *gocui.Gui) error {
-	maxX, maxY := g.Size()
-	if v, err := g.SetView("menu", maxX/4, maxY/4, 3*maxX/4, 3*maxY/4); err != nil {
-		if err != gocui.ErrUnknownView {
-			return err
+	if *size != "" {
+		width, height, err := board.ParseSize(*size)
+		if err != nil {
+			log.Fatalf("invalid board size: %v", err)
 		}
-		v.Title = "Main Menu"
-		fmt.Fprintln(v, "1. Start Game")
-		fmt.Fprintln(v, "Q. Quit")
-		if _, err := g.SetCurrentView("menu"); err != nil {
-			return err
+		rules = rules.WithSize(width, height)
+		if err := rules.Validate(); err != nil {
+			log.Fatalf("unable to play the rules on a %v board: %v", rules.Size(), err)
 		}
 	}
-	return nil
-}
-
-func layoutGrid(g *gocui.Gui) error {
-	maxX, _ := g.Size()
 
-	for i := 0; i < gridSize; i++ {
-		for j := 0; j < gridSize; j++ {
-			viewName := grid[i][j]
-			v, err := g.SetView(viewName, j*4, i*2, j*4+3, i*2+1)
-			if err != nil && err != gocui.ErrUnknownView {
-				return err
-			}
-			v.Title = viewName
+	strategies := player.Strategies()
+	if *placement != "all" {
+		strategy, err := player.StrategyByName(*placement)
+		if err != nil {
+			log.Fatalf("unknown placement strategy %q", *placement)
 		}
+		strategies = []player.PlacementStrategy{strategy}
 	}
 
-	if v, err := g.SetView("side", maxX-20, 0, maxX-1, 10); err != nil {
-		if err != gocui.ErrUnknownView {
-			return err
+	engines := sim.Engines()
+	if *engine != "all" {
+		found, err := sim.EngineByName(*engine)
+		if err != nil {
+			log.Fatalf("unknown shooting engine %q", *engine)
 		}
-		v.Title = "Last Clicked"
-		fmt.Fprintln(v, "None")
-	}
-
-	return nil
-}
-
-func switchToGrid(g *gocui.Gui, v *gocui.View) error {
-	currentView = "grid"
-	g.SetManagerFunc(layout)
-	updateKeybindings(g)
-	return nil
-}
-
-func switchToMenu(g *gocui.Gui, v *gocui.View) error {
-	currentView = "menu"
-	g.SetManagerFunc(layout)
-	updateKeybindings(g)
-	return nil
-}
-
-func mouseClick(g *gocui.Gui, v *gocui.View) error {
-	viewName := v.Name()
-	lastClicked = viewName
-	sideView, err := g.View("side")
-	if err != nil {
-		return err
+		engines = []sim.Engine{found}
 	}
-	sideView.Clear()
-	fmt.Fprintf(sideView, "Last clicked: %s\n", lastClicked)
-	return nil
-}
-
-func keybindingsMenu(g *gocui.Gui) error {
-	if err := g.SetKeybinding("menu", '1', gocui.ModNone, switchToGrid); err != nil {
-		return err
-	}
-	if err := g.SetKeybinding("menu", 'q', gocui.ModNone, func(g *gocui.Gui, v *gocui.View) error {
-		return gocui.ErrQuit
-	}); err != nil {
-		return err
-	}
-	return nil
-}
 
-func keybindingsGrid(g *gocui.Gui) error {
-	for i := 0; i < gridSize; i++ {
-		for j := 0; j < gridSize; j++ {
-			viewName := grid[i][j]
-			if err := g.SetKeybinding(viewName, gocui.MouseLeft, gocui.ModNone, mouseClick); err != nil {
-				return err
+	fmt.Printf("Rules: %s (%s)\n", rules.Name, rules.Size())
+	fmt.Printf("Seed: %d\n", *seed)
+
+	for _, shooter := range engines {
+		for _, strategy := range strategies {
+			cfg := sim.Config{Games: *games, Seed: *seed, Workers: *workers, Rules: rules, Placement: strategy, Engine: shooter}
+			cfg.KeepMoves = *records != ""
+			start := time.Now()
+			results := sim.Run(cfg)
+			elapsed := time.Since(start)
+
+			fmt.Printf("\n== Engine: %s, Placement: %s (%v) ==\n", shooter.Name, strategy.Name(), elapsed.Round(time.Millisecond))
+			stats := sim.NewStats(results)
+			stats.Print(os.Stdout, *width)
+
+			for _, game := range results {
+				if game.Won {
+					continue
+				}
+				if *verbose {
+					fmt.Printf("seed %d abandoned after %d turns: %v\n", game.Seed, game.Turns, game.Err)
+				}
+				if *records != "" {
+					if err := writeRecord(*records, shooter.Name, strategy.Name(), rules, game); err != nil {
+						log.Fatal(err)
+					}
+				}
 			}
 		}
 	}
-	if err := g.SetKeybinding("", 'm', gocui.ModNone, switchToMenu); err != nil {
-		return err
-	}
-	if err := g.SetKeybinding("", 'q', gocui.ModNone, func(g *gocui.Gui, v *gocui.View) error {
-		return gocui.ErrQuit
-	}); err != nil {
-		return err
-	}
-	return nil
-}
-
-func updateKeybindings(g *gocui.Gui) error {
-	g.DeleteKeybindings("")
-	if currentView == "menu" {
-		return keybindingsMenu(g)
-	}
-	return keybindingsGrid(g)
-}
-
-func main() {
-	initGrid()
-
-	g, err := gocui.NewGui(gocui.OutputNormal)
-	if err != nil {
-		log.Panicln(err)
-	}
-	defer g.Close()
-
-	g.SetManagerFunc(layout)
-
-	if err := updateKeybindings(g); err != nil {
-		log.Panicln(err)
-	}
-
-	if err := g.MainLoop(); err != nil && err != gocui.ErrQuit {
-		log.Panicln(err)
-	}
 }
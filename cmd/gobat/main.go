@@ -1,10 +1,34 @@
 package main
 
 import (
+	"flag"
+	"log"
+
+	"github.com/eaglerock1337/gobat/pkg/board"
 	"github.com/eaglerock1337/gobat/pkg/gobat"
 )
 
 func main() {
-	screen := gobat.NewTerminal()
-	gobat.Run(screen)
+	rulesFlag := flag.String("rules", board.DefaultRuleset().Name, "built-in ruleset name, or the path to a JSON ruleset file")
+	size := flag.String("size", "", "board size as width by height (e.g. 8x8 or 12x12), overriding the rules")
+	flag.Parse()
+
+	rules, err := board.LoadRuleset(*rulesFlag)
+	if err != nil {
+		log.Fatalf("unable to load the rules: %v", err)
+	}
+	if *size != "" {
+		width, height, err := board.ParseSize(*size)
+		if err != nil {
+			log.Fatalf("invalid board size: %v", err)
+		}
+		rules = rules.WithSize(width, height)
+		if err := rules.Validate(); err != nil {
+			log.Fatalf("unable to play the rules on a %v board: %v", rules.Size(), err)
+		}
+	}
+
+	if err := gobat.NewAppWithRules(rules).Run(); err != nil {
+		log.Fatalf("unable to run the terminal: %v", err)
+	}
 }
@@ -0,0 +1,122 @@
+package board
+
+import "math/bits"
+
+// BitboardSize is the widest and tallest board whose squares all fit in a
+// Bitboard.
+const BitboardSize = 16
+
+// Bitboard is a set of squares held as one bit per square, so that squares
+// can be added, searched and compared with a few bitwise operations rather
+// than a loop over every square. Only the first BitboardSize letters and
+// numbers fit, which covers every board up to 16x16.
+type Bitboard [BitboardSize * BitboardSize / 64]uint64
+
+// Bitboard creation functions
+
+// NewBitboard returns a Bitboard holding the given squares, and whether
+// every one of them fits in a Bitboard.
+func NewBitboard(squares []Square) (Bitboard, bool) {
+	var b Bitboard
+	for _, square := range squares {
+		if !b.Add(square) {
+			return Bitboard{}, false
+		}
+	}
+	return b, true
+}
+
+// FitsBitboard returns whether every square of the Board fits in a Bitboard.
+func (b Board) FitsBitboard() bool {
+	return b.Width() <= BitboardSize && b.Height() <= BitboardSize
+}
+
+// bitboardBit returns the word and bit of a Square in a Bitboard, and
+// whether the Square fits in a Bitboard at all.
+func bitboardBit(s Square) (int, uint64, bool) {
+	if uint(s.Letter) >= BitboardSize || uint(s.Number) >= BitboardSize {
+		return 0, 0, false
+	}
+	bit := s.Letter*BitboardSize + s.Number
+	return bit / 64, 1 << (bit % 64), true
+}
+
+// Bitboard update methods
+
+// Add adds a Square to the Bitboard, returning false if it does not fit.
+func (b *Bitboard) Add(s Square) bool {
+	word, bit, ok := bitboardBit(s)
+	if ok {
+		b[word] |= bit
+	}
+	return ok
+}
+
+// Bitboard retrieval methods
+
+// Has returns whether the Square is in the Bitboard.
+func (b Bitboard) Has(s Square) bool {
+	word, bit, ok := bitboardBit(s)
+	return ok && b[word]&bit != 0
+}
+
+// IsEmpty returns whether the Bitboard holds no squares.
+func (b Bitboard) IsEmpty() bool {
+	return b[0]|b[1]|b[2]|b[3] == 0
+}
+
+// Count returns the number of squares in the Bitboard.
+func (b Bitboard) Count() int {
+	return bits.OnesCount64(b[0]) + bits.OnesCount64(b[1]) + bits.OnesCount64(b[2]) + bits.OnesCount64(b[3])
+}
+
+// First returns the first Square in the Bitboard, column by column, and
+// whether there is one.
+func (b Bitboard) First() (Square, bool) {
+	for word, value := range b {
+		if value != 0 {
+			bit := word*64 + bits.TrailingZeros64(value)
+			return Square{bit / BitboardSize, bit % BitboardSize}, true
+		}
+	}
+	return Square{}, false
+}
+
+// Squares returns every Square in the Bitboard, column by column.
+func (b Bitboard) Squares() []Square {
+	squares := make([]Square, 0, b.Count())
+	for word, value := range b {
+		for ; value != 0; value &= value - 1 {
+			bit := word*64 + bits.TrailingZeros64(value)
+			squares = append(squares, Square{bit / BitboardSize, bit % BitboardSize})
+		}
+	}
+	return squares
+}
+
+// Bitboard comparison methods
+
+// Overlaps returns whether the two Bitboards have any squares in common.
+func (b Bitboard) Overlaps(o Bitboard) bool {
+	return b[0]&o[0]|b[1]&o[1]|b[2]&o[2]|b[3]&o[3] != 0
+}
+
+// Covers returns whether the Bitboard holds every square of the other.
+func (b Bitboard) Covers(o Bitboard) bool {
+	return o[0]&^b[0]|o[1]&^b[1]|o[2]&^b[2]|o[3]&^b[3] == 0
+}
+
+// Union returns a Bitboard of the squares in either Bitboard.
+func (b Bitboard) Union(o Bitboard) Bitboard {
+	return Bitboard{b[0] | o[0], b[1] | o[1], b[2] | o[2], b[3] | o[3]}
+}
+
+// Intersect returns a Bitboard of the squares in both Bitboards.
+func (b Bitboard) Intersect(o Bitboard) Bitboard {
+	return Bitboard{b[0] & o[0], b[1] & o[1], b[2] & o[2], b[3] & o[3]}
+}
+
+// Without returns a Bitboard of the squares not in the other Bitboard.
+func (b Bitboard) Without(o Bitboard) Bitboard {
+	return Bitboard{b[0] &^ o[0], b[1] &^ o[1], b[2] &^ o[2], b[3] &^ o[3]}
+}
@@ -0,0 +1,90 @@
+package board
+
+import (
+	"testing"
+)
+
+var exampleBitboardSquares = []Square{{0, 0}, {3, 7}, {9, 9}, {3, 8}, {15, 15}}
+
+var exampleOutsideSquares = []Square{{-1, 0}, {16, 0}, {0, 16}, {20, 30}}
+
+func TestNewBitboard(t *testing.T) {
+	mask, ok := NewBitboard(exampleBitboardSquares)
+	if !ok {
+		t.Errorf("NewBitboard did not fit squares on a 16x16 board: %v", exampleBitboardSquares)
+	}
+
+	for _, square := range exampleBitboardSquares {
+		if !mask.Has(square) {
+			t.Errorf("Bitboard did not hold added square %v", square.PrintSquare())
+		}
+	}
+	for _, square := range append(testSquares[:], exampleOutsideSquares...) {
+		if mask.Has(square) && !containsSquare(exampleBitboardSquares, square) {
+			t.Errorf("Bitboard held square %v that was never added", square.PrintSquare())
+		}
+	}
+	if mask.Count() != len(exampleBitboardSquares) {
+		t.Errorf("Count did not return %v, got %v", len(exampleBitboardSquares), mask.Count())
+	}
+
+	for _, square := range exampleOutsideSquares {
+		if mask, ok := NewBitboard([]Square{square}); ok {
+			t.Errorf("NewBitboard did not fail on square %v, returned %v", square, mask)
+		}
+	}
+}
+
+func TestBitboardSquares(t *testing.T) {
+	mask, _ := NewBitboard(exampleBitboardSquares)
+	expected := []Square{{0, 0}, {3, 7}, {3, 8}, {9, 9}, {15, 15}}
+
+	squares := mask.Squares()
+	if len(squares) != len(expected) {
+		t.Errorf("Squares did not return %v, got %v", expected, squares)
+	}
+	for i, square := range squares {
+		if i < len(expected) && square != expected[i] {
+			t.Errorf("Squares did not return %v, got %v", expected, squares)
+		}
+	}
+
+	if first, found := mask.First(); !found || first != expected[0] {
+		t.Errorf("First did not return %v, got %v", expected[0], first)
+	}
+	if first, found := (Bitboard{}).First(); found || !(Bitboard{}).IsEmpty() {
+		t.Errorf("First returned %v for an empty Bitboard", first)
+	}
+}
+
+func TestBitboardCompare(t *testing.T) {
+	mask, _ := NewBitboard(exampleBitboardSquares)
+	other, _ := NewBitboard([]Square{{3, 8}, {4, 8}})
+	apart, _ := NewBitboard([]Square{{5, 5}})
+
+	if !mask.Overlaps(other) || mask.Overlaps(apart) {
+		t.Errorf("Overlaps returned unexpected results for %v, %v and %v", mask, other, apart)
+	}
+	if !mask.Union(other).Covers(mask) || !mask.Union(other).Covers(other) || mask.Covers(other) {
+		t.Errorf("Union or Covers returned unexpected results for %v and %v", mask, other)
+	}
+	if squares := mask.Intersect(other).Squares(); len(squares) != 1 || squares[0] != (Square{3, 8}) {
+		t.Errorf("Intersect did not return D9, got %v", squares)
+	}
+	if mask.Without(other).Count() != 4 || mask.Without(other).Has(Square{3, 8}) {
+		t.Errorf("Without did not remove D9, got %v", mask.Without(other).Squares())
+	}
+}
+
+func TestFitsBitboard(t *testing.T) {
+	for size, expected := range map[[2]int]bool{{10, 10}: true, {16, 16}: true, {17, 10}: false, {8, 20}: false} {
+		if NewBoard(size[0], size[1]).FitsBitboard() != expected {
+			t.Errorf("FitsBitboard did not return %v for a %vx%v board", expected, size[0], size[1])
+		}
+	}
+
+	piece, _ := DefaultRuleset().WithSize(20, 20).NewPiece(Ship("Destroyer"), Square{18, 0}, true)
+	if piece.IsMasked() || !piece.InSquare(Square{19, 0}) {
+		t.Errorf("NewPiece set a mask on a board too large for a Bitboard, got %v", piece)
+	}
+}
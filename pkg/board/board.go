@@ -14,14 +14,26 @@ member methods of the above two types allow for Piece to be used with Board easi
 by referencing coordinates by its zero-based array location. Just like the above
 types, Piece should only be created by its creation method for validation purposes.
 
-Board is a simple 10x10 array of integers that can be defined normally without a
-creation function ("var myBoard Board"). The above types can reference this 2D
-array through their methods. The different board status values (e.g. Empty, Hit)
-correspond to integers for fast searching, comparison, and boolean methods.
+Board is a 2D slice of integers, created at a given width and height with NewBoard
+(10x10 for the standard game, but anywhere from 1x1 to 16x16). Columns are lettered
+from A and rows are numbered from 1. The above types can reference this 2D slice
+through their methods, and the Board checks that squares are within its bounds.
+The zero value of a Board is nil and has no squares, so Boards should always be
+created with NewBoard or Ruleset.NewBoard. The different board status values (e.g. Empty,
+Hit) correspond to integers for fast searching, comparison, and boolean methods.
+
+A Ruleset defines the variant of Battleship being played: the fleet of ships with
+their lengths and counts, the size of the board, and whether the type of a sunk
+ship is announced. Built-in rulesets are provided for the Milton Bradley 1967 rules
+and for the Russian fleet of ten ships, and others can be loaded from JSON. Ships are always one of the types above, but their lengths come from
+the Ruleset, so Ruleset.NewPiece should be used to create pieces under other rules.
 */
 package board
 
-import "errors"
+import (
+	"errors"
+	"fmt"
+)
 
 // These two variables allow for conversion of each square status to
 // the status string and vice-versa. This allows for statuses to be stored
@@ -38,15 +50,137 @@ var (
 	}
 )
 
-// Board is a type for holding a standard 10x10 Battleship game board.
-type Board [10][10]int
+// DefaultSize is the width and height of a standard Battleship board.
+const DefaultSize = 10
+
+// MaxSize is the largest width or height of a board. Coordinates of up to
+// two letters and two digits can be parsed, but every square of a board has
+// to fit in a Bitboard so that each Piece on it has a Mask.
+const MaxSize = BitboardSize
+
+// Board is a type for holding a Battleship game board of any size, indexed
+// by column (letter) and then by row (number). The zero value is a nil Board
+// with no squares: Contains is false for every Square, Square and
+// SquareByString return errors, Around returns no squares, and the methods
+// getting or checking the value of a Square panic.
+type Board [][]int
+
+// Board creation functions
+
+// NewBoard creates an empty Board with the given width and height.
+func NewBoard(width, height int) Board {
+	b := make(Board, width)
+	for i := range b {
+		b[i] = make([]int, height)
+	}
+	return b
+}
+
+// Clone returns a copy of the Board that does not share its squares.
+func (b Board) Clone() Board {
+	clone := NewBoard(b.Width(), b.Height())
+	for i := range b {
+		copy(clone[i], b[i])
+	}
+	return clone
+}
+
+// Board size methods
+
+// Width returns the number of columns (letters) on the Board.
+func (b Board) Width() int {
+	return len(b)
+}
+
+// Height returns the number of rows (numbers) on the Board.
+func (b Board) Height() int {
+	if len(b) == 0 {
+		return 0
+	}
+	return len(b[0])
+}
+
+// Contains returns whether a given Square is within the bounds of the Board.
+func (b Board) Contains(s Square) bool {
+	return s.Letter >= 0 && s.Letter < b.Width() && s.Number >= 0 && s.Number < b.Height()
+}
+
+// Square creates a Square by letter and number integers, returning an
+// error if it is not within the bounds of the Board.
+func (b Board) Square(let, num int) (Square, error) {
+	square := Square{let, num}
+	if !b.Contains(square) {
+		return Square{}, errors.New("String coordinates out of bounds")
+	}
+	return square, nil
+}
+
+// SquareByString creates a Square by a string of the coordinates, returning
+// an error if it is not within the bounds of the Board.
+func (b Board) SquareByString(coords string) (Square, error) {
+	square, err := SquareByString(coords)
+	if err != nil {
+		return Square{}, err
+	}
+	if !b.Contains(square) {
+		return Square{}, fmt.Errorf("Square %v is not on the %dx%d board", square.PrintSquare(), b.Width(), b.Height())
+	}
+	return square, nil
+}
+
+// Squares returns every Square on the Board, column by column.
+func (b Board) Squares() []Square {
+	squares := make([]Square, 0, b.Width()*b.Height())
+	for i := 0; i < b.Width(); i++ {
+		for j := 0; j < b.Height(); j++ {
+			squares = append(squares, Square{i, j})
+		}
+	}
+	return squares
+}
+
+// Around returns every square on the Board touching the given Piece,
+// including diagonally, that is not part of the Piece itself.
+func (b Board) Around(p Piece) []Square {
+	var around []Square
+	for _, pieceSquare := range p.Coords {
+		for let := -1; let <= 1; let++ {
+			for num := -1; num <= 1; num++ {
+				square := Square{pieceSquare.Letter + let, pieceSquare.Number + num}
+				if !b.Contains(square) || p.InSquare(square) || containsSquare(around, square) {
+					continue
+				}
+				around = append(around, square)
+			}
+		}
+	}
+	return around
+}
+
+// Equal returns whether two Boards are the same size with the same values.
+func (b Board) Equal(o Board) bool {
+	if b.Width() != o.Width() || b.Height() != o.Height() {
+		return false
+	}
+	for i := range b {
+		for j := range b[i] {
+			if b[i][j] != o[i][j] {
+				return false
+			}
+		}
+	}
+	return true
+}
 
 // Board update methods
 
 // SetString sets a board value to a given string value.
 func (b *Board) SetString(s Square, value string) error {
+	if !b.Contains(s) {
+		return errors.New("Given square is not on the board")
+	}
 	if val, ok := values[value]; ok {
-		b[s.Letter][s.Number] = val
+		(*b)[s.Letter][s.Number] = val
 		return nil
 	}
 	return errors.New("Given value is not a valid value")
@@ -54,8 +188,11 @@ func (b *Board) SetString(s Square, value string) error {
 
 // SetInt sets a board value to a given integer value.
 func (b *Board) SetInt(s Square, value int) error {
+	if !b.Contains(s) {
+		return errors.New("Given square is not on the board")
+	}
 	if value > 0 && value < 8 {
-		b[s.Letter][s.Number] = value
+		(*b)[s.Letter][s.Number] = value
 		return nil
 	}
 	return errors.New("Given value out of range")
@@ -74,6 +211,9 @@ func (b *Board) SetPiece(p Piece) {
 // as opposed to tracking hits and misses.
 func (b *Board) PlacePiece(p Piece) error {
 	for _, square := range p.Coords {
+		if !b.Contains(square) {
+			return errors.New("Piece coordinates are not on the board")
+		}
 		if !b.IsEmpty(square) {
 			return errors.New("Piece coordinates are not empty")
 		}
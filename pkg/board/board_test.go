@@ -17,7 +17,7 @@ var boardStrings = [5]string{
 }
 
 func TestSetString(t *testing.T) {
-	var testboard Board
+	testboard := NewBoard(10, 10)
 
 	for i, input := range boardSquares {
 		err := testboard.SetString(input, boardStrings[i])
@@ -36,7 +36,7 @@ var badBoardStrs = [5]string{
 }
 
 func TestBadSetString(t *testing.T) {
-	var testboard Board
+	testboard := NewBoard(10, 10)
 
 	for i, input := range boardSquares {
 		err := testboard.SetString(input, badBoardStrs[i])
@@ -54,7 +54,7 @@ func TestBadSetString(t *testing.T) {
 var boardIntegers = [5]int{1, 2, 5, 6, 7}
 
 func TestSetInt(t *testing.T) {
-	var testboard Board
+	testboard := NewBoard(10, 10)
 
 	for i, input := range boardSquares {
 		err := testboard.SetInt(input, boardIntegers[i])
@@ -71,7 +71,7 @@ func TestSetInt(t *testing.T) {
 var badBoardInts = [5]int{-1, 8, 20, -3, 17}
 
 func TestBadSetInt(t *testing.T) {
-	var testboard Board
+	testboard := NewBoard(10, 10)
 
 	for i, input := range boardSquares {
 		err := testboard.SetInt(input, badBoardInts[i])
@@ -87,17 +87,17 @@ func TestBadSetInt(t *testing.T) {
 }
 
 var boardTestPieces = [5]Piece{
-	{Ship("Carrier"), []Square{{0, 7}, {1, 7}, {2, 7}, {3, 7}, {4, 7}}},
-	{Ship("Battleship"), []Square{{7, 2}, {7, 3}, {7, 4}, {7, 5}}},
-	{Ship("Cruiser"), []Square{{5, 8}, {6, 8}, {7, 8}}},
-	{Ship("Submarine"), []Square{{9, 6}, {9, 7}, {9, 8}}},
-	{Ship("Destroyer"), []Square{{6, 9}, {7, 9}}},
+	{Type: Ship("Carrier"), Coords: []Square{{0, 7}, {1, 7}, {2, 7}, {3, 7}, {4, 7}}},
+	{Type: Ship("Battleship"), Coords: []Square{{7, 2}, {7, 3}, {7, 4}, {7, 5}}},
+	{Type: Ship("Cruiser"), Coords: []Square{{5, 8}, {6, 8}, {7, 8}}},
+	{Type: Ship("Submarine"), Coords: []Square{{9, 6}, {9, 7}, {9, 8}}},
+	{Type: Ship("Destroyer"), Coords: []Square{{6, 9}, {7, 9}}},
 }
 
 var boardPieceValues = [5]int{6, 5, 4, 3, 2}
 
 func TestSetPiece(t *testing.T) {
-	var testboard Board
+	testboard := NewBoard(10, 10)
 
 	for i, input := range boardTestPieces {
 		testboard.SetPiece(input)
@@ -109,7 +109,7 @@ func TestSetPiece(t *testing.T) {
 }
 
 func TestPlacePiece(t *testing.T) {
-	var testboard Board
+	testboard := NewBoard(10, 10)
 
 	for i, input := range boardTestPieces {
 		err := testboard.PlacePiece(input)
@@ -132,7 +132,7 @@ var boardPieceSquares = [5]Square{
 }
 
 func TestBadPlacePiece(t *testing.T) {
-	var testboard Board
+	testboard := NewBoard(10, 10)
 
 	for _, square := range boardPieceSquares {
 		testboard[square.Letter][square.Number] = 7
@@ -151,7 +151,7 @@ func TestBadPlacePiece(t *testing.T) {
 }
 
 func TestGetString(t *testing.T) {
-	var testboard Board
+	testboard := NewBoard(10, 10)
 
 	for i, input := range boardSquares {
 		testboard[input.Letter][input.Number] = boardIntegers[i]
@@ -164,7 +164,7 @@ func TestGetString(t *testing.T) {
 }
 
 func TestGetInt(t *testing.T) {
-	var testboard Board
+	testboard := NewBoard(10, 10)
 
 	for i, input := range boardSquares {
 		testboard[input.Letter][input.Number] = boardIntegers[i]
@@ -179,7 +179,7 @@ func TestGetInt(t *testing.T) {
 var boardTestVals = [5]int{0, 1, 2, 4, 7}
 
 func TestIsEmpty(t *testing.T) {
-	var testboard Board
+	testboard := NewBoard(10, 10)
 	var expected = [5]bool{true, false, false, false, false}
 
 	for i, input := range boardSquares {
@@ -193,7 +193,7 @@ func TestIsEmpty(t *testing.T) {
 }
 
 func TestIsMiss(t *testing.T) {
-	var testboard Board
+	testboard := NewBoard(10, 10)
 	var expected = [5]bool{false, true, false, false, false}
 
 	for i, input := range boardSquares {
@@ -207,7 +207,7 @@ func TestIsMiss(t *testing.T) {
 }
 
 func TestIsHit(t *testing.T) {
-	var testboard Board
+	testboard := NewBoard(10, 10)
 	var expected = [5]bool{false, false, true, true, true}
 
 	for i, input := range boardSquares {
@@ -221,7 +221,7 @@ func TestIsHit(t *testing.T) {
 }
 
 func TestIsUnsunk(t *testing.T) {
-	var testboard Board
+	testboard := NewBoard(10, 10)
 	var expected = [5]bool{false, false, false, false, true}
 
 	for i, input := range boardSquares {
@@ -235,7 +235,7 @@ func TestIsUnsunk(t *testing.T) {
 }
 
 func TestIsSunk(t *testing.T) {
-	var testboard Board
+	testboard := NewBoard(10, 10)
 	var expected = [5]bool{false, false, true, true, false}
 
 	for i, input := range boardSquares {
@@ -257,7 +257,7 @@ var boardTestShips = [5]Ship{
 }
 
 func TestIsShip(t *testing.T) {
-	var testboard Board
+	testboard := NewBoard(10, 10)
 	var expected = [5]bool{false, false, false, true, false}
 
 	for i, input := range boardSquares {
@@ -269,3 +269,95 @@ func TestIsShip(t *testing.T) {
 		}
 	}
 }
+
+func TestAround(t *testing.T) {
+	testboard := NewBoard(10, 10)
+	corner := Piece{Type: Ship("Destroyer"), Coords: []Square{{Letter: 0, Number: 0}, {Letter: 1, Number: 0}}}
+	if around := testboard.Around(corner); len(around) != 4 {
+		t.Errorf("Around did not return 4 squares for a Destroyer in the corner, got %v", around)
+	}
+
+	middle := Piece{Type: Ship("Cruiser"), Coords: []Square{{Letter: 4, Number: 3}, {Letter: 4, Number: 4}, {Letter: 4, Number: 5}}}
+	around := testboard.Around(middle)
+	if len(around) != 12 {
+		t.Errorf("Around did not return 12 squares for a Cruiser in the middle, got %v", around)
+	}
+	for _, square := range around {
+		if middle.InSquare(square) {
+			t.Errorf("Around returned square %v of the piece itself", square.PrintSquare())
+		}
+	}
+}
+
+func TestNewBoard(t *testing.T) {
+	for _, size := range [][2]int{{8, 8}, {10, 10}, {12, 12}, {15, 10}} {
+		testboard := NewBoard(size[0], size[1])
+		if testboard.Width() != size[0] || testboard.Height() != size[1] {
+			t.Errorf("NewBoard did not create a %vx%v board, got %vx%v", size[0], size[1], testboard.Width(), testboard.Height())
+		}
+		if len(testboard.Squares()) != size[0]*size[1] {
+			t.Errorf("Squares did not return all %v squares, got %v", size[0]*size[1], len(testboard.Squares()))
+		}
+	}
+}
+
+func TestNilBoard(t *testing.T) {
+	var testboard Board
+	if testboard.Width() != 0 || testboard.Height() != 0 || len(testboard.Squares()) != 0 {
+		t.Errorf("Board zero value did not have 0 squares, got %vx%v", testboard.Width(), testboard.Height())
+	}
+	if answer, err := testboard.Square(0, 0); err == nil {
+		t.Errorf("Square did not error as expected on a nil board, returned Square: %v", answer)
+	}
+	if answer, err := testboard.SquareByString("A1"); err == nil {
+		t.Errorf("SquareByString did not error as expected on a nil board, returned Square: %v", answer)
+	}
+
+	piece := Piece{Type: Ship("Destroyer"), Coords: []Square{{Letter: 0, Number: 0}, {Letter: 1, Number: 0}}}
+	if around := testboard.Around(piece); len(around) != 0 {
+		t.Errorf("Around did not return 0 squares on a nil board, got %v", around)
+	}
+}
+
+var outOfBounds = []string{"K1", "A11", "Z1", "AA10", "B11"}
+
+func TestBoardSquareByString(t *testing.T) {
+	testboard := NewBoard(10, 10)
+	for _, input := range outOfBounds {
+		if answer, err := testboard.SquareByString(input); err == nil {
+			t.Errorf("SquareByString did not error as expected with %v on a 10x10 board, returned Square: %v", input, answer)
+		}
+		square, _ := SquareByString(input)
+		if answer, err := testboard.Square(square.Letter, square.Number); err == nil {
+			t.Errorf("Square did not error as expected with %v on a 10x10 board, returned Square: %v", input, answer)
+		}
+	}
+
+	large := NewBoard(15, 15)
+	for _, input := range []string{"K1", "A11", "L15", "O15"} {
+		if _, err := large.SquareByString(input); err != nil {
+			t.Errorf("SquareByString returned an error for %v on a 15x15 board: %v", input, err)
+		}
+	}
+	if answer, err := large.SquareByString("P1"); err == nil {
+		t.Errorf("SquareByString did not error as expected with P1 on a 15x15 board, returned Square: %v", answer)
+	}
+}
+
+func TestCloneEqual(t *testing.T) {
+	testboard := NewBoard(12, 12)
+	testboard.SetString(Square{11, 11}, "Hit")
+	clone := testboard.Clone()
+
+	if !clone.Equal(testboard) {
+		t.Errorf("Clone did not return an equal board")
+	}
+
+	clone.SetString(Square{0, 0}, "Miss")
+	if clone.Equal(testboard) || testboard.IsMiss(Square{0, 0}) {
+		t.Errorf("Clone returned a board sharing squares with the original")
+	}
+	if NewBoard(10, 10).Equal(NewBoard(10, 12)) {
+		t.Errorf("Equal returned true for boards of different sizes")
+	}
+}
@@ -17,10 +17,13 @@ var shipNames = [5]string{"Carrier", "Battleship", "Cruiser", "Submarine", "Dest
 // Ship is a string of the ship type with extra methods.
 type Ship string
 
-// Piece is a struct for defining a piece and its position.
+// Piece is a struct for defining a piece and its position. The Mask holds
+// the same squares as the Coords for fast comparisons, and is only set for
+// pieces created by NewPiece on a board that fits in a Bitboard.
 type Piece struct {
 	Type   Ship
 	Coords []Square
+	Mask   Bitboard
 }
 
 // Ship creation functions
@@ -49,7 +52,8 @@ func (s Ship) GetType() string {
 	return string(s)
 }
 
-// GetLength will return the length of the ship as an integer.
+// GetLength will return the length of the ship as an integer under the
+// default rules. Use Ruleset.Length for the length under other rules.
 func (s Ship) GetLength() int {
 	return ships[string(s)]
 }
@@ -57,13 +61,20 @@ func (s Ship) GetLength() int {
 // Piece creation function
 
 // NewPiece defines a Piece by a ship type, a starting coordinate, and the
-// direction (horizontal or vertical), and returns a Piece and error result.
+// direction (horizontal or vertical) on a standard board, and returns a
+// Piece and error result.
 func NewPiece(shipType Ship, startSquare Square, horizontal bool) (Piece, error) {
+	return newPiece(shipType, shipType.GetLength(), startSquare, horizontal, DefaultSize, DefaultSize)
+}
+
+// newPiece defines a Piece of the given length on a board of the given
+// size, both of which may differ from the standard game under other rules.
+func newPiece(shipType Ship, length int, startSquare Square, horizontal bool, width, height int) (Piece, error) {
 	var newPiece Piece
 	newPiece.Type = shipType
-	newPiece.Coords = make([]Square, 0, shipType.GetLength())
+	newPiece.Coords = make([]Square, 0, length)
 
-	for i := 0; i < shipType.GetLength(); i++ {
+	for i := 0; i < length; i++ {
 		letter := startSquare.Letter
 		number := startSquare.Number
 		if horizontal {
@@ -72,26 +83,31 @@ func NewPiece(shipType Ship, startSquare Square, horizontal bool) (Piece, error)
 			number += i
 		}
 
-		square, error := SquareByValue(letter, number)
-		if error != nil {
+		if letter < 0 || number < 0 || letter >= width || number >= height {
 			return newPiece, errors.New("Ship location is out of bounds")
 		}
-		newPiece.Coords = append(newPiece.Coords, square)
+		newPiece.Coords = append(newPiece.Coords, Square{letter, number})
 	}
 
+	if width <= BitboardSize && height <= BitboardSize {
+		newPiece.Mask, _ = NewBitboard(newPiece.Coords)
+	}
 	return newPiece, nil
 }
 
 // Piece boolean methods
 
+// IsMasked returns whether the Piece has a Mask of its squares.
+func (p Piece) IsMasked() bool {
+	return !p.Mask.IsEmpty()
+}
+
 // InSquare is a function for determining if a Piece is in a Square.
 func (p Piece) InSquare(s Square) bool {
-	for _, pieceSquare := range p.Coords {
-		if pieceSquare == s {
-			return true
-		}
+	if p.IsMasked() {
+		return p.Mask.Has(s)
 	}
-	return false
+	return containsSquare(p.Coords, s)
 }
 
 // InList is a function for determining if a Piece is in a slice of Squares.
@@ -108,8 +124,11 @@ func (p Piece) InList(list []Square) bool {
 }
 
 // InPiece is a function for determining if a Piece is in a slice of Squares.
-// (this is O(n**2), so let's try not to use it)
+// (this is O(n**2) for pieces without a Mask, so let's try not to use it)
 func (p Piece) InPiece(compare Piece) bool {
+	if p.IsMasked() && compare.IsMasked() {
+		return p.Mask.Overlaps(compare.Mask)
+	}
 	for _, pieceSquare := range p.Coords {
 		for _, square := range compare.Coords {
 			if pieceSquare == square {
@@ -119,3 +138,13 @@ func (p Piece) InPiece(compare Piece) bool {
 	}
 	return false
 }
+
+// containsSquare returns whether the given square is in the list of squares.
+func containsSquare(list []Square, s Square) bool {
+	for _, square := range list {
+		if square == s {
+			return true
+		}
+	}
+	return false
+}
@@ -90,11 +90,11 @@ var pieceSquares = [5]Square{
 }
 
 var examplePieces = [5]Piece{
-	{Ship("Carrier"), []Square{{0, 7}, {1, 7}, {2, 7}, {3, 7}, {4, 7}}},
-	{Ship("Battleship"), []Square{{7, 2}, {7, 3}, {7, 4}, {7, 5}}},
-	{Ship("Cruiser"), []Square{{5, 8}, {6, 8}, {7, 8}}},
-	{Ship("Submarine"), []Square{{9, 6}, {9, 7}, {9, 8}}},
-	{Ship("Destroyer"), []Square{{6, 9}, {7, 9}}},
+	{Type: Ship("Carrier"), Coords: []Square{{0, 7}, {1, 7}, {2, 7}, {3, 7}, {4, 7}}},
+	{Type: Ship("Battleship"), Coords: []Square{{7, 2}, {7, 3}, {7, 4}, {7, 5}}},
+	{Type: Ship("Cruiser"), Coords: []Square{{5, 8}, {6, 8}, {7, 8}}},
+	{Type: Ship("Submarine"), Coords: []Square{{9, 6}, {9, 7}, {9, 8}}},
+	{Type: Ship("Destroyer"), Coords: []Square{{6, 9}, {7, 9}}},
 }
 
 func TestNewPiece(t *testing.T) {
@@ -113,6 +113,12 @@ func TestNewPiece(t *testing.T) {
 				if coord != examplePieces[i].Coords[j] {
 					t.Errorf("NewPiece coordinates were incorrect, got: %v, want: %v", coord, examplePieces[i].Coords[j])
 				}
+				if !answer.Mask.Has(coord) {
+					t.Errorf("NewPiece mask did not hold coordinate %v", coord)
+				}
+			}
+			if answer.Mask.Count() != len(answer.Coords) {
+				t.Errorf("NewPiece mask held %v squares, want: %v", answer.Mask.Count(), len(answer.Coords))
 			}
 		}
 	}
@@ -132,13 +138,19 @@ func TestBadNewPiece(t *testing.T) {
 
 var testSquares = [5]Square{{2, 7}, {3, 3}, {7, 8}, {9, 5}, {6, 9}}
 
+// masked returns the Piece with its Mask set, as NewPiece would set it.
+func masked(p Piece) Piece {
+	p.Mask, _ = NewBitboard(p.Coords)
+	return p
+}
+
 func TestInSquare(t *testing.T) {
 	expected := [5]bool{true, false, true, false, true}
 
 	for i, input := range examplePieces {
 		answer := input.InSquare(testSquares[i])
 
-		if answer != expected[i] {
+		if answer != expected[i] || masked(input).InSquare(testSquares[i]) != answer {
 			t.Errorf("InSquare was incorrect with: %v, want: %v", input, expected[i])
 		}
 	}
@@ -165,11 +177,11 @@ func TestInList(t *testing.T) {
 }
 
 var testPieces = [5]Piece{
-	{Ship("Destroyer"), []Square{{2, 7}, {2, 8}}},
-	{Ship("Submarine"), []Square{{0, 6}, {1, 6}, {2, 6}}},
-	{Ship("Cruiser"), []Square{{2, 2}, {3, 2}, {4, 2}}},
-	{Ship("Destroyer"), []Square{{8, 7}, {9, 7}}},
-	{Ship("Destroyer"), []Square{{5, 9}, {6, 9}}},
+	{Type: Ship("Destroyer"), Coords: []Square{{2, 7}, {2, 8}}},
+	{Type: Ship("Submarine"), Coords: []Square{{0, 6}, {1, 6}, {2, 6}}},
+	{Type: Ship("Cruiser"), Coords: []Square{{2, 2}, {3, 2}, {4, 2}}},
+	{Type: Ship("Destroyer"), Coords: []Square{{8, 7}, {9, 7}}},
+	{Type: Ship("Destroyer"), Coords: []Square{{5, 9}, {6, 9}}},
 }
 
 func TestInPiece(t *testing.T) {
@@ -178,8 +190,18 @@ func TestInPiece(t *testing.T) {
 	for i, input := range examplePieces {
 		answer := input.InPiece(testPieces[i])
 
-		if answer != expected[i] {
+		if answer != expected[i] || masked(input).InPiece(masked(testPieces[i])) != answer {
 			t.Errorf("InPiece was incorrect with: %v, want: %v", input, expected[i])
 		}
 	}
 }
+
+func BenchmarkInSquare(b *testing.B) {
+	for name, piece := range map[string]Piece{"coords": examplePieces[0], "mask": masked(examplePieces[0])} {
+		b.Run(name, func(b *testing.B) {
+			for i := 0; i < b.N; i++ {
+				piece.InSquare(testSquares[i%len(testSquares)])
+			}
+		})
+	}
+}
@@ -0,0 +1,307 @@
+package board
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"os"
+	"sort"
+	"strings"
+)
+
+// FleetShip is a single entry in the fleet of a Ruleset: a type of ship,
+// its length, and how many of them each player has.
+type FleetShip struct {
+	Ship   Ship `json:"ship"`
+	Length int  `json:"length"`
+	Count  int  `json:"count"`
+}
+
+// Ruleset is a struct that defines a variant of Battleship: the fleet each
+// player places, the size of the board, and what is announced after a shot.
+// Some rules also keep ships from touching, which is how a sunk ship can be
+// found without its type being announced.
+type Ruleset struct {
+	Name         string      `json:"name"`         // The name of the rules, e.g. Milton Bradley 1967
+	Width        int         `json:"width"`        // The number of lettered columns on the board
+	Height       int         `json:"height"`       // The number of numbered rows on the board
+	Fleet        []FleetShip `json:"fleet"`        // The ships each player places
+	AnnounceShip bool        `json:"announceShip"` // Whether the type of a sunk ship is announced
+	NoTouching   bool        `json:"noTouching"`   // Whether ships are kept from touching, even diagonally
+}
+
+// Ruleset creation functions
+
+// DefaultRuleset returns the standard Milton Bradley rules.
+func DefaultRuleset() Ruleset {
+	return Rulesets()[0]
+}
+
+// Rulesets returns every built-in ruleset, starting with the default. The
+// Hasbro 2002 fleet is not built in: its Destroyer and Submarine have the same
+// lengths as the Milton Bradley fleet, and its Patrol Boat is not one of the
+// types of ship a Board can hold.
+func Rulesets() []Ruleset {
+	return []Ruleset{
+		{
+			Name: "Milton Bradley 1967", Width: 10, Height: 10, AnnounceShip: true,
+			Fleet: []FleetShip{
+				{"Carrier", 5, 1}, {"Battleship", 4, 1}, {"Cruiser", 3, 1},
+				{"Submarine", 3, 1}, {"Destroyer", 2, 1},
+			},
+		},
+		{
+			Name: "Russian", Width: 10, Height: 10, AnnounceShip: false, NoTouching: true,
+			Fleet: []FleetShip{
+				{"Battleship", 4, 1}, {"Cruiser", 3, 2},
+				{"Destroyer", 2, 3}, {"Submarine", 1, 4},
+			},
+		},
+	}
+}
+
+// RulesetByName returns the built-in ruleset with the given name.
+func RulesetByName(name string) (Ruleset, error) {
+	for _, rules := range Rulesets() {
+		if strings.EqualFold(rules.Name, name) {
+			return rules, nil
+		}
+	}
+	return Ruleset{}, fmt.Errorf("no built-in ruleset named %q", name)
+}
+
+// ParseRuleset reads a ruleset written as JSON and validates it. JSON is the
+// only format read, and fields other than those of a Ruleset are rejected.
+func ParseRuleset(r io.Reader) (Ruleset, error) {
+	var rules Ruleset
+	decoder := json.NewDecoder(r)
+	decoder.DisallowUnknownFields()
+	if err := decoder.Decode(&rules); err != nil {
+		return Ruleset{}, fmt.Errorf("unable to decode the ruleset: %v", err)
+	}
+
+	if err := rules.Validate(); err != nil {
+		return Ruleset{}, err
+	}
+	return rules, nil
+}
+
+// LoadRuleset returns the built-in ruleset with the given name, or reads
+// one from the JSON file at the given path if there is no such ruleset.
+func LoadRuleset(nameOrPath string) (Ruleset, error) {
+	if rules, err := RulesetByName(nameOrPath); err == nil {
+		return rules, nil
+	}
+
+	file, err := os.Open(nameOrPath)
+	if err != nil {
+		return Ruleset{}, fmt.Errorf("%q is not a built-in ruleset or a ruleset file: %v", nameOrPath, err)
+	}
+	defer file.Close()
+
+	return ParseRuleset(file)
+}
+
+// Ruleset validation method
+
+// Validate returns an error if the ruleset does not describe a playable game.
+// Every ship must be one of the types a Board can hold, listed only once. If
+// the type of a sunk ship is not announced, every ship must have a different
+// length, so that the type can be told from the length of the ship. The whole
+// fleet must also fit on the board at once, apart from each other if ships
+// may not touch.
+func (r Ruleset) Validate() error {
+	if r.Name == "" {
+		return errors.New("ruleset must have a name")
+	}
+	if r.Width < 1 || r.Height < 1 || r.Width > MaxSize || r.Height > MaxSize {
+		return fmt.Errorf("board size %dx%d is not between 1x1 and %dx%d", r.Width, r.Height, MaxSize, MaxSize)
+	}
+	if len(r.Fleet) == 0 {
+		return errors.New("ruleset must have at least one ship")
+	}
+
+	squares, spaced := 0, 0
+	seen := make(map[Ship]bool)
+	lengths := make(map[int]bool)
+	for _, entry := range r.Fleet {
+		if _, err := NewShip(entry.Ship.GetType()); err != nil {
+			return fmt.Errorf("%q is not a valid ship type", entry.Ship)
+		}
+		if seen[entry.Ship] {
+			return fmt.Errorf("ship %v is listed more than once", entry.Ship)
+		}
+		seen[entry.Ship] = true
+
+		if entry.Length < 1 || (entry.Length > r.Width && entry.Length > r.Height) {
+			return fmt.Errorf("ship %v has a length of %d, which does not fit the board", entry.Ship, entry.Length)
+		}
+		if entry.Count < 1 {
+			return fmt.Errorf("ship %v must have a count of at least one", entry.Ship)
+		}
+		if !r.AnnounceShip && lengths[entry.Length] {
+			return fmt.Errorf("ship %v has the same length as another ship, so its type must be announced when sunk", entry.Ship)
+		}
+		lengths[entry.Length] = true
+		squares += entry.Length * entry.Count
+		spaced += (entry.Length + 1) * 2 * entry.Count
+	}
+
+	if squares > r.Width*r.Height {
+		return fmt.Errorf("the fleet needs %d squares, more than the board has", squares)
+	}
+	// a ship with the squares below and to the right of it covers a 2 by length
+	// plus one block, and these blocks cannot overlap when ships may not touch
+	if r.NoTouching && spaced > (r.Width+1)*(r.Height+1) {
+		return fmt.Errorf("the fleet needs %d squares to keep its ships apart, more than the board has", spaced)
+	}
+	if !r.fleetFits() {
+		return errors.New("the fleet cannot all be placed on the board at once")
+	}
+	return nil
+}
+
+// Ruleset retrieval methods
+
+// Ships returns every ship in the fleet, with a ship listed once for each
+// of its count, in the order of the fleet.
+func (r Ruleset) Ships() []Ship {
+	var fleet []Ship
+	for _, entry := range r.Fleet {
+		for i := 0; i < entry.Count; i++ {
+			fleet = append(fleet, entry.Ship)
+		}
+	}
+	return fleet
+}
+
+// Length returns the length of the given ship, or 0 if it is not in the fleet.
+func (r Ruleset) Length(s Ship) int {
+	for _, entry := range r.Fleet {
+		if entry.Ship == s {
+			return entry.Length
+		}
+	}
+	return 0
+}
+
+// Count returns how many of the given ship are in the fleet.
+func (r Ruleset) Count(s Ship) int {
+	for _, entry := range r.Fleet {
+		if entry.Ship == s {
+			return entry.Count
+		}
+	}
+	return 0
+}
+
+// NewPiece defines a Piece the same way as the NewPiece function, but with
+// the ship's length taken from the ruleset.
+func (r Ruleset) NewPiece(shipType Ship, startSquare Square, horizontal bool) (Piece, error) {
+	length := r.Length(shipType)
+	if length == 0 {
+		return Piece{}, fmt.Errorf("ship %v is not in the fleet", shipType)
+	}
+	return newPiece(shipType, length, startSquare, horizontal, r.Width, r.Height)
+}
+
+// NewBoard creates an empty Board of the size given by the ruleset.
+func (r Ruleset) NewBoard() Board {
+	return NewBoard(r.Width, r.Height)
+}
+
+// Size returns the size of the board as a string, e.g. 10x10.
+func (r Ruleset) Size() string {
+	return fmt.Sprintf("%dx%d", r.Width, r.Height)
+}
+
+// WithSize returns a copy of the ruleset played on a board of the given
+// width and height, which must still be validated.
+func (r Ruleset) WithSize(width, height int) Ruleset {
+	r.Width, r.Height = width, height
+	return r
+}
+
+// ParseSize parses a board size written as width by height (e.g. 12x12).
+func ParseSize(size string) (int, int, error) {
+	var width, height int
+	if n, err := fmt.Sscanf(strings.ToLower(size), "%dx%d", &width, &height); err != nil || n != 2 {
+		return 0, 0, fmt.Errorf("board size %q is not written as width by height, e.g. 12x12", size)
+	}
+	if width < 1 || height < 1 || width > MaxSize || height > MaxSize {
+		return 0, 0, fmt.Errorf("board size %dx%d is not between 1x1 and %dx%d", width, height, MaxSize, MaxSize)
+	}
+	return width, height, nil
+}
+
+// fleetSearchLimit is the most placements tried when searching for a way to
+// place a fleet whose ships may not touch, after which it is assumed to fit.
+const fleetSearchLimit = 200000
+
+// fleetFits returns whether every ship of the fleet can be placed on the board
+// at once, without overlapping or, if the rules say so, touching. Ships are
+// placed longest first, and ships of the same length only in increasing
+// order of their first square, so no arrangement is searched twice. The fleet
+// is assumed to fit if the search runs out of steps.
+func (r Ruleset) fleetFits() bool {
+	var lengths []int
+	for _, entry := range r.Fleet {
+		for i := 0; i < entry.Count; i++ {
+			lengths = append(lengths, entry.Length)
+		}
+	}
+	sort.Sort(sort.Reverse(sort.IntSlice(lengths)))
+
+	steps := fleetSearchLimit
+	placed := r.NewBoard()
+	var place func(ship, from int) bool
+	place = func(ship, from int) bool {
+		if ship == len(lengths) {
+			return true
+		}
+		if ship > 0 && lengths[ship] != lengths[ship-1] {
+			from = 0
+		}
+
+		for start := from; start < r.Width*r.Height*2; start++ {
+			if steps <= 0 {
+				return true
+			}
+			square := Square{Letter: start / 2 % r.Width, Number: start / 2 / r.Width}
+			piece, err := newPiece("", lengths[ship], square, start%2 == 0, r.Width, r.Height)
+			if err != nil || (lengths[ship] == 1 && start%2 == 1) || !r.isOpen(placed, piece) {
+				continue
+			}
+
+			steps--
+			for _, s := range piece.Coords {
+				placed[s.Letter][s.Number] = values["Hit"]
+			}
+			fits := place(ship+1, start+1)
+			for _, s := range piece.Coords {
+				placed[s.Letter][s.Number] = values["Empty"]
+			}
+			if fits {
+				return true
+			}
+		}
+		return false
+	}
+	return place(0, 0)
+}
+
+// isOpen returns whether every square of the piece is empty on the board,
+// along with every square around it if the rules keep ships from touching.
+func (r Ruleset) isOpen(placed Board, piece Piece) bool {
+	squares := piece.Coords
+	if r.NoTouching {
+		squares = append(placed.Around(piece), squares...)
+	}
+	for _, square := range squares {
+		if !placed.IsEmpty(square) {
+			return false
+		}
+	}
+	return true
+}
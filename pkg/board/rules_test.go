@@ -0,0 +1,174 @@
+package board
+
+import (
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+)
+
+var exampleFleetSizes = map[string]int{
+	"Milton Bradley 1967": 5,
+	"Russian":             10,
+}
+
+func TestRulesets(t *testing.T) {
+	for _, rules := range Rulesets() {
+		if err := rules.Validate(); err != nil {
+			t.Errorf("Ruleset %v did not validate: %v", rules.Name, err)
+		}
+		if len(rules.Ships()) != exampleFleetSizes[rules.Name] {
+			t.Errorf("Ruleset %v did not have %v ships, got %v", rules.Name, exampleFleetSizes[rules.Name], rules.Ships())
+		}
+	}
+
+	if DefaultRuleset().Name != "Milton Bradley 1967" {
+		t.Errorf("DefaultRuleset did not return the Milton Bradley rules, got %v", DefaultRuleset().Name)
+	}
+	for i, ship := range DefaultRuleset().Ships() {
+		if ship != exampleShips[i] || DefaultRuleset().Length(ship) != ship.GetLength() {
+			t.Errorf("DefaultRuleset did not match the standard fleet, got %v", DefaultRuleset().Fleet)
+		}
+	}
+}
+
+func TestRulesetByName(t *testing.T) {
+	russian, err := RulesetByName("russian")
+	if err != nil {
+		t.Errorf("RulesetByName returned an unexpected error: %v", err)
+	}
+	if russian.Name != "Russian" || russian.Length(Ship("Submarine")) != 1 {
+		t.Errorf("RulesetByName did not return the Russian fleet, got %v", russian.Fleet)
+	}
+
+	if rules, err := RulesetByName("Calvinball"); err == nil {
+		t.Errorf("RulesetByName did not error as expected, returned %v", rules.Name)
+	}
+}
+
+func TestRussianRuleset(t *testing.T) {
+	russian, _ := RulesetByName("Russian")
+
+	expected := map[Ship][2]int{
+		Ship("Battleship"): {4, 1},
+		Ship("Cruiser"):    {3, 2},
+		Ship("Destroyer"):  {2, 3},
+		Ship("Submarine"):  {1, 4},
+		Ship("Carrier"):    {0, 0},
+	}
+	for ship, want := range expected {
+		if russian.Length(ship) != want[0] || russian.Count(ship) != want[1] {
+			t.Errorf("Russian rules did not have %v %v of length %v, got %v of length %v", want[1], ship, want[0], russian.Count(ship), russian.Length(ship))
+		}
+	}
+
+	if russian.AnnounceShip || !russian.NoTouching {
+		t.Errorf("Russian rules did not hide sunk ship types and keep ships apart, got %v", russian)
+	}
+}
+
+func TestRussianRulesetSize(t *testing.T) {
+	russian, _ := RulesetByName("Russian")
+
+	if err := russian.WithSize(7, 7).Validate(); err != nil {
+		t.Errorf("Russian rules did not validate on a 7x7 board: %v", err)
+	}
+	if err := russian.WithSize(6, 6).Validate(); err == nil {
+		t.Errorf("Russian rules did not error as expected on a 6x6 board")
+	}
+}
+
+func TestRulesetNewPiece(t *testing.T) {
+	russian, _ := RulesetByName("Russian")
+	start := Square{Letter: 2, Number: 2}
+
+	piece, err := russian.NewPiece(Ship("Submarine"), start, true)
+	if err != nil || len(piece.Coords) != 1 || piece.Coords[0] != start {
+		t.Errorf("NewPiece did not create a single square Submarine, got %v: %v", piece, err)
+	}
+
+	if piece, err := russian.NewPiece(Ship("Carrier"), start, true); err == nil {
+		t.Errorf("NewPiece did not error for a ship outside the fleet, returned %v", piece)
+	}
+	if piece, err := russian.NewPiece(Ship("Battleship"), Square{Letter: 8, Number: 0}, true); err == nil {
+		t.Errorf("NewPiece did not error for an out of bounds ship, returned %v", piece)
+	}
+}
+
+var exampleRulesetJSON = `{
+	"name": "Small Fleet",
+	"width": 10,
+	"height": 10,
+	"fleet": [
+		{"ship": "Battleship", "length": 4, "count": 1},
+		{"ship": "Destroyer", "length": 2, "count": 2}
+	],
+	"announceShip": true
+}`
+
+func TestParseRuleset(t *testing.T) {
+	rules, err := ParseRuleset(strings.NewReader(exampleRulesetJSON))
+	if err != nil {
+		t.Errorf("ParseRuleset returned an unexpected error: %v", err)
+	}
+	if rules.Name != "Small Fleet" || len(rules.Ships()) != 3 || rules.Length(Ship("Destroyer")) != 2 {
+		t.Errorf("ParseRuleset did not read the ruleset, got %v", rules)
+	}
+}
+
+var badRulesets = []string{
+	`{"name": "Typo", "widht": 10}`,
+	`{"name": "Wide", "width": 17, "height": 10, "fleet": [{"ship": "Carrier", "length": 5, "count": 1}]}`,
+	`{"name": "Empty", "width": 10, "height": 10, "fleet": []}`,
+	`{"name": "Yacht", "width": 10, "height": 10, "fleet": [{"ship": "Yacht", "length": 2, "count": 1}]}`,
+	`{"name": "Twice", "width": 10, "height": 10, "fleet": [{"ship": "Carrier", "length": 5, "count": 1}, {"ship": "Carrier", "length": 4, "count": 1}]}`,
+	`{"name": "Long", "width": 10, "height": 10, "fleet": [{"ship": "Carrier", "length": 11, "count": 1}]}`,
+	`{"name": "None", "width": 10, "height": 10, "fleet": [{"ship": "Carrier", "length": 5, "count": 0}]}`,
+	`{"name": "Crowded", "width": 10, "height": 10, "fleet": [{"ship": "Carrier", "length": 5, "count": 21}]}`,
+	`{"name": "Quiet", "width": 10, "height": 10, "fleet": [{"ship": "Cruiser", "length": 3, "count": 1}, {"ship": "Submarine", "length": 3, "count": 1}]}`,
+	`{"name": "Apart", "width": 10, "height": 10, "noTouching": true, "fleet": [{"ship": "Submarine", "length": 1, "count": 31}]}`,
+	`{"name": "Touching", "width": 2, "height": 2, "noTouching": true, "fleet": [{"ship": "Submarine", "length": 1, "count": 2}]}`,
+	`{"width": 10, "height": 10, "fleet": [{"ship": "Carrier", "length": 5, "count": 1}]}`,
+}
+
+func TestBadParseRuleset(t *testing.T) {
+	for _, input := range badRulesets {
+		if rules, err := ParseRuleset(strings.NewReader(input)); err == nil {
+			t.Errorf("ParseRuleset did not error as expected with %v, returned %v", input, rules)
+		}
+	}
+}
+
+func TestLoadRuleset(t *testing.T) {
+	if rules, err := LoadRuleset("Russian"); err != nil || rules.Name != "Russian" {
+		t.Errorf("LoadRuleset did not return the built-in Russian rules, got %v: %v", rules.Name, err)
+	}
+
+	path := filepath.Join(t.TempDir(), "small.json")
+	os.WriteFile(path, []byte(exampleRulesetJSON), 0644)
+	if rules, err := LoadRuleset(path); err != nil || rules.Name != "Small Fleet" {
+		t.Errorf("LoadRuleset did not read the ruleset file, got %v: %v", rules.Name, err)
+	}
+
+	if _, err := LoadRuleset(filepath.Join(t.TempDir(), "missing.json")); err == nil {
+		t.Errorf("LoadRuleset did not error with a missing file")
+	}
+}
+
+var exampleSizeStrings = map[string][2]int{"8x8": {8, 8}, "12X15": {12, 15}, "16x16": {16, 16}}
+
+var badSizes = []string{"", "10", "0x10", "17x17", "10x52", "axb"}
+
+func TestParseSize(t *testing.T) {
+	for input, expected := range exampleSizeStrings {
+		if width, height, err := ParseSize(input); err != nil || width != expected[0] || height != expected[1] {
+			t.Errorf("ParseSize did not parse %v, got %vx%v: %v", input, width, height, err)
+		}
+	}
+
+	for _, input := range badSizes {
+		if width, height, err := ParseSize(input); err == nil {
+			t.Errorf("ParseSize did not error as expected with %v, returned %vx%v", input, width, height)
+		}
+	}
+}
@@ -7,12 +7,13 @@ import (
 	"strings"
 )
 
-// columns allows easy converstion of a column name to its index. The
-// opposite conversion can be done by using strconv.Atoi() instead.
-var columns = map[string]int{
-	"A": 0, "B": 1, "C": 2, "D": 3, "E": 4,
-	"F": 5, "G": 6, "H": 7, "I": 8, "J": 9,
-}
+// The most letters and digits a coordinate string can have. These cover far
+// more than a board of MaxSize, so only the format of a coordinate is checked
+// when it is parsed, and the bounds are checked by the Board.
+const (
+	maxLetters = 2
+	maxDigits  = 2
+)
 
 // Square is a struct for holding a coordinate on the board.
 type Square struct {
@@ -22,48 +23,52 @@ type Square struct {
 
 // Square creation functions
 
-// SquareByValue creates a Square by letter and number integers.
+// SquareByValue creates a Square by letter and number integers on a
+// standard 10x10 board. Use Board.Square for boards of other sizes.
 func SquareByValue(let int, num int) (Square, error) {
-	if let < 0 || let > 9 || num < 0 || num > 9 {
+	if let < 0 || let >= DefaultSize || num < 0 || num >= DefaultSize {
 		return Square{}, errors.New("String coordinates out of bounds")
 	}
 	return Square{let, num}, nil
 }
 
-// SquareByString creates a Square by a string of the coordinates.
+// SquareByString creates a Square by a string of the coordinates, with
+// one or more column letters followed by the row number (e.g. C3, L15 or
+// AA12). Columns past Z continue as AA, AB and so on. Only the format is
+// checked here; use Board.SquareByString to also check the bounds of a board.
 func SquareByString(coords string) (Square, error) {
-	chars := strings.Split(coords, "")
-	var letstr, numstr string
-
-	if len(chars) < 2 || len(chars) > 3 {
+	coords = strings.ToUpper(coords)
+	split := strings.IndexFunc(coords, func(r rune) bool { return r < 'A' || r > 'Z' })
+	if split < 1 || split > maxLetters || len(coords)-split > maxDigits {
 		return Square{}, errors.New("Coordinate string is improperly sized")
-	} else if len(chars) == 3 {
-		letstr = chars[0]
-		numstr = chars[1] + chars[2]
-	} else {
-		letstr = chars[0]
-		numstr = chars[1]
 	}
 
-	if let, found := columns[strings.ToUpper(letstr)]; found {
-		num, err := strconv.Atoi(numstr)
-		if err != nil {
-			return Square{}, errors.New("String number could not convert to integer")
-		} else if num < 1 || num > 10 {
-			return Square{}, errors.New("String number coordinate out of bounds")
-		}
+	let := 0
+	for _, char := range coords[:split] {
+		let = let*26 + int(char-'A') + 1
+	}
 
-		return Square{let, num - 1}, nil
+	digits := coords[split:]
+	num, err := strconv.Atoi(digits)
+	if err != nil || strings.IndexFunc(digits, func(r rune) bool { return r < '0' || r > '9' }) >= 0 {
+		return Square{}, errors.New("String number could not convert to integer")
+	} else if num < 1 {
+		return Square{}, errors.New("String number coordinate out of bounds")
 	}
 
-	return Square{}, errors.New("String letter coordinate not found")
+	return Square{let - 1, num - 1}, nil
 }
 
 // Square retrieval methods
 
-// PrintLetter returns the column (letter) as a string.
+// PrintLetter returns the column (letter) as a string, continuing past Z
+// with AA, AB and so on.
 func (s Square) PrintLetter() string {
-	return string(rune('A' - 0 + s.Letter))
+	letters := ""
+	for let := s.Letter + 1; let > 0; let = (let - 1) / 26 {
+		letters = string(rune('A'+(let-1)%26)) + letters
+	}
+	return letters
 }
 
 // PrintNumber returns the row (number) as a string.
@@ -71,7 +76,24 @@ func (s Square) PrintNumber() string {
 	return fmt.Sprint(s.Number + 1)
 }
 
-// PrintSquare returns the column and row as a two-letter string.
+// PrintSquare returns the column and row as a string, e.g. B7 or AA12.
 func (s Square) PrintSquare() string {
 	return s.PrintLetter() + s.PrintNumber()
 }
+
+// Square encoding methods
+
+// MarshalText encodes the Square as its coordinate string (e.g. B7).
+func (s Square) MarshalText() ([]byte, error) {
+	return []byte(s.PrintSquare()), nil
+}
+
+// UnmarshalText decodes the Square from its coordinate string (e.g. B7).
+func (s *Square) UnmarshalText(text []byte) error {
+	square, err := SquareByString(string(text))
+	if err != nil {
+		return err
+	}
+	*s = square
+	return nil
+}
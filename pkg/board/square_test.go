@@ -67,8 +67,19 @@ func TestSquareByString(t *testing.T) {
 
 var badStrings = [5]string{"Z1", "3A", "AA10", "AA1", "B11"}
 
+var badFormats = [5]string{"3A", "A0", "AAA1", "B+1", "C"}
+
 func TestBadSquareByString(t *testing.T) {
+	standard := NewBoard(DefaultSize, DefaultSize)
 	for _, input := range badStrings {
+		answer, err := standard.SquareByString(input)
+
+		if err == nil {
+			t.Errorf("SquareByString did not error as expected with %v, returned Square: %v", input, answer)
+		}
+	}
+
+	for _, input := range badFormats {
 		answer, err := SquareByString(input)
 
 		if err == nil {
@@ -77,6 +88,28 @@ func TestBadSquareByString(t *testing.T) {
 	}
 }
 
+var exampleLargeSquares = map[string]Square{
+	"Z1":   {25, 0},
+	"AA12": {26, 11},
+	"ab3":  {27, 2},
+	"L15":  {11, 14},
+	"AZ52": {51, 51},
+}
+
+func TestLargeSquareByString(t *testing.T) {
+	for input, expected := range exampleLargeSquares {
+		answer, err := SquareByString(input)
+
+		if err != nil {
+			t.Errorf("SquareByString returned an error for %v: %v", input, err)
+		} else if answer != expected {
+			t.Errorf("SquareByString function was incorrect, got: %v, want: %v", answer, expected)
+		} else if answer.PrintSquare() != strings.ToUpper(input) {
+			t.Errorf("PrintSquare did not return %v, got %v", strings.ToUpper(input), answer.PrintSquare())
+		}
+	}
+}
+
 func TestPrintLetter(t *testing.T) {
 	results := [5]string{"A", "C", "F", "J", "G"}
 
@@ -107,3 +140,32 @@ func TestPrintSquare(t *testing.T) {
 		}
 	}
 }
+
+func TestMarshalText(t *testing.T) {
+	for i, input := range examples {
+		answer, err := input.MarshalText()
+		if err != nil {
+			t.Errorf("MarshalText returned an error: %v", err)
+		} else if string(answer) != input.PrintSquare() {
+			t.Errorf("MarshalText was incorrect, got: %v, want: %v", string(answer), examples[i].PrintSquare())
+		}
+	}
+}
+
+func TestUnmarshalText(t *testing.T) {
+	for i, input := range exampleStrings {
+		var answer Square
+		if err := answer.UnmarshalText([]byte(input)); err != nil {
+			t.Errorf("UnmarshalText returned an error: %v", err)
+		} else if answer != examples[i] {
+			t.Errorf("UnmarshalText was incorrect, got: %v, want: %v", answer, examples[i])
+		}
+	}
+
+	for _, input := range badFormats {
+		var answer Square
+		if err := answer.UnmarshalText([]byte(input)); err == nil {
+			t.Errorf("UnmarshalText did not error as expected with %v, returned Square: %v", input, answer)
+		}
+	}
+}
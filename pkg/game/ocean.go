@@ -0,0 +1,149 @@
+/*
+Package game implements the referee side of a game of Battleship. It is used for
+driving the hunter algorithm automatically, such as in the simulator or in
+self-play, without a human opponent announcing the result of each shot.
+
+The Ocean type holds a hidden fleet fully placed on a board.Board, and scores shots
+against it. Each shot returns the same result strings that hunter.Hunter.Turn
+accepts: "Miss", "Hit", or the name of the ship that was just sunk, or "Sunk" when
+the board.Ruleset does not announce the type of a sunk ship. The Ocean keeps track
+of the damage to each ship so it knows when a ship has been sunk, and rejects any
+square that has already been shot.
+*/
+package game
+
+import (
+	"errors"
+	"fmt"
+
+	"github.com/eaglerock1337/gobat/pkg/board"
+)
+
+// Ocean is a struct that holds a hidden fleet and scores shots against it.
+type Ocean struct {
+	Board  board.Board   // The board with the hidden fleet placed on it
+	Shots  board.Board   // The board of squares shot so far and their results
+	Fleet  []board.Piece // The pieces of the hidden fleet
+	Damage []int         // The number of hits taken by each piece of the fleet
+	Afloat int           // The number of ships not yet sunk
+	Rules  board.Ruleset // The rules the fleet was placed under
+}
+
+// NewOcean creates an Ocean from a list of pieces under the default rules,
+// placing each of them on the board. It returns an error if any pieces
+// overlap or a ship is repeated.
+func NewOcean(fleet []board.Piece) (Ocean, error) {
+	return NewOceanWithRules(board.DefaultRuleset(), fleet)
+}
+
+// NewOceanWithRules creates an Ocean from a list of pieces under the given
+// rules. It returns an error if any pieces overlap, run off the board, or
+// touch where the rules do not allow it, or if a ship is not in the rules'
+// fleet, has the wrong length, or is placed too many times.
+func NewOceanWithRules(rules board.Ruleset, fleet []board.Piece) (Ocean, error) {
+	ocean := Ocean{Board: rules.NewBoard(), Shots: rules.NewBoard()}
+	placed := make(map[board.Ship]int)
+
+	for _, piece := range fleet {
+		if rules.Length(piece.Type) != len(piece.Coords) {
+			return Ocean{}, fmt.Errorf("ship %v does not have a length of %d under the rules", piece.Type, len(piece.Coords))
+		}
+		if placed[piece.Type] == rules.Count(piece.Type) {
+			return Ocean{}, fmt.Errorf("ship %v is placed more times than the rules allow", piece.Type)
+		}
+		if rules.NoTouching {
+			for _, square := range ocean.Board.Around(piece) {
+				if !ocean.Board.IsEmpty(square) {
+					return Ocean{}, fmt.Errorf("ship %v touches the %v, which the rules do not allow", piece.Type, ocean.Board.GetString(square))
+				}
+			}
+		}
+		if err := ocean.Board.PlacePiece(piece); err != nil {
+			return Ocean{}, fmt.Errorf("unable to place %v: %v", piece.Type, err)
+		}
+		placed[piece.Type]++
+	}
+
+	ocean.Fleet = fleet
+	ocean.Damage = make([]int, len(fleet))
+	ocean.Afloat = len(fleet)
+	ocean.Rules = rules
+	return ocean, nil
+}
+
+// Fire scores a shot at the given square, returning "Miss", "Hit", or the name
+// of the ship that was sunk, or "Sunk" if the rules do not announce its type.
+// An error is returned if the square is off the board or was already shot.
+func (o *Ocean) Fire(s board.Square) (string, error) {
+	if !o.Shots.Contains(s) {
+		return "", fmt.Errorf("square %v is not on the %v board", s.PrintSquare(), o.Rules.Size())
+	}
+	if !o.Shots.IsEmpty(s) {
+		return "", fmt.Errorf("square %v has already been shot", s.PrintSquare())
+	}
+
+	if o.Board.IsEmpty(s) {
+		o.Shots.SetString(s, "Miss")
+		return "Miss", nil
+	}
+
+	i := o.pieceAt(s)
+	if i < 0 {
+		return "", errors.New("ship not found in the fleet")
+	}
+	piece := o.Fleet[i]
+
+	o.Shots.SetString(s, "Hit")
+	o.Damage[i]++
+	if o.Damage[i] < len(piece.Coords) {
+		return "Hit", nil
+	}
+
+	o.Afloat--
+	o.Shots.SetPiece(piece)
+	if !o.Rules.AnnounceShip {
+		return "Sunk", nil
+	}
+	return piece.Type.GetType(), nil
+}
+
+// pieceAt returns the index of the piece of the fleet on the given square,
+// or -1 if there is none.
+func (o Ocean) pieceAt(s board.Square) int {
+	for i, piece := range o.Fleet {
+		if piece.InSquare(s) {
+			return i
+		}
+	}
+	return -1
+}
+
+// Piece returns the first piece of the fleet for the given ship.
+func (o Ocean) Piece(sh board.Ship) (board.Piece, error) {
+	for _, piece := range o.Fleet {
+		if piece.Type == sh {
+			return piece, nil
+		}
+	}
+	return board.Piece{}, errors.New("ship not found in the fleet")
+}
+
+// IsSunk returns whether every ship of the given type has been sunk.
+func (o Ocean) IsSunk(sh board.Ship) bool {
+	found := false
+	for i, piece := range o.Fleet {
+		if piece.Type != sh {
+			continue
+		}
+		if o.Damage[i] < len(piece.Coords) {
+			return false
+		}
+		found = true
+	}
+	return found
+}
+
+// Defeated returns whether every ship in the fleet has been sunk.
+func (o Ocean) Defeated() bool {
+	return o.Afloat == 0
+}
@@ -0,0 +1,190 @@
+package game
+
+import (
+	"testing"
+
+	"github.com/eaglerock1337/gobat/pkg/board"
+)
+
+var exampleFleet = []board.Piece{
+	{Type: board.Ship("Carrier"), Coords: []board.Square{{Letter: 0, Number: 7}, {Letter: 1, Number: 7}, {Letter: 2, Number: 7}, {Letter: 3, Number: 7}, {Letter: 4, Number: 7}}},
+	{Type: board.Ship("Battleship"), Coords: []board.Square{{Letter: 7, Number: 2}, {Letter: 7, Number: 3}, {Letter: 7, Number: 4}, {Letter: 7, Number: 5}}},
+	{Type: board.Ship("Cruiser"), Coords: []board.Square{{Letter: 5, Number: 8}, {Letter: 6, Number: 8}, {Letter: 7, Number: 8}}},
+	{Type: board.Ship("Submarine"), Coords: []board.Square{{Letter: 9, Number: 6}, {Letter: 9, Number: 7}, {Letter: 9, Number: 8}}},
+	{Type: board.Ship("Destroyer"), Coords: []board.Square{{Letter: 6, Number: 9}, {Letter: 7, Number: 9}}},
+}
+
+func TestNewOcean(t *testing.T) {
+	testOcean, err := NewOcean(exampleFleet)
+
+	if err != nil {
+		t.Errorf("NewOcean returned an unexpected error: %v", err)
+	}
+
+	if testOcean.Afloat != 5 {
+		t.Errorf("NewOcean did not report 5 ships afloat, got %v", testOcean.Afloat)
+	}
+
+	for _, piece := range exampleFleet {
+		for _, square := range piece.Coords {
+			if !testOcean.Board.IsShip(square, piece.Type) {
+				t.Errorf("NewOcean did not place %v on square %v", piece.Type, square.PrintSquare())
+			}
+		}
+	}
+}
+
+var badFleets = [3][]board.Piece{
+	{exampleFleet[0], exampleFleet[0]},
+	{exampleFleet[1], {Type: board.Ship("Destroyer"), Coords: []board.Square{{Letter: 7, Number: 5}, {Letter: 7, Number: 6}}}},
+	{{Type: board.Ship("Destroyer"), Coords: []board.Square{{Letter: 9, Number: 5}, {Letter: 10, Number: 5}}}},
+}
+
+func TestBadNewOcean(t *testing.T) {
+	for _, fleet := range badFleets {
+		if _, err := NewOcean(fleet); err == nil {
+			t.Errorf("NewOcean did not error as expected with fleet %v", fleet)
+		}
+	}
+}
+
+var exampleShots = []struct {
+	square string
+	result string
+}{
+	{"A1", "Miss"},
+	{"H3", "Hit"},
+	{"G10", "Hit"},
+	{"H10", "Destroyer"},
+	{"H4", "Hit"},
+	{"H5", "Hit"},
+	{"H6", "Battleship"},
+	{"J10", "Miss"},
+}
+
+func TestFire(t *testing.T) {
+	testOcean, _ := NewOcean(exampleFleet)
+
+	for _, shot := range exampleShots {
+		square, _ := board.SquareByString(shot.square)
+		result, err := testOcean.Fire(square)
+
+		if err != nil {
+			t.Errorf("Fire returned an unexpected error for %v: %v", shot.square, err)
+		}
+		if result != shot.result {
+			t.Errorf("Fire was incorrect for %v, got: %v, want: %v", shot.square, result, shot.result)
+		}
+	}
+
+	if testOcean.Afloat != 3 {
+		t.Errorf("Fire did not leave 3 ships afloat, got %v", testOcean.Afloat)
+	}
+
+	if !testOcean.IsSunk(board.Ship("Battleship")) || testOcean.IsSunk(board.Ship("Carrier")) {
+		t.Errorf("IsSunk did not report the Battleship sunk and the Carrier afloat: %v", testOcean.Damage)
+	}
+
+	square, _ := board.SquareByString("H3")
+	if !testOcean.Shots.IsShip(square, board.Ship("Battleship")) {
+		t.Errorf("Fire did not mark the sunk Battleship on the Shots board, got %v", testOcean.Shots.GetString(square))
+	}
+}
+
+func TestRepeatedFire(t *testing.T) {
+	testOcean, _ := NewOcean(exampleFleet)
+
+	for _, coords := range []string{"A1", "H3"} {
+		square, _ := board.SquareByString(coords)
+		testOcean.Fire(square)
+
+		if result, err := testOcean.Fire(square); err == nil {
+			t.Errorf("Fire did not error on a repeated shot at %v, returned %v", coords, result)
+		}
+	}
+
+	if testOcean.Damage[1] != 1 {
+		t.Errorf("Fire counted damage from a repeated shot, got %v", testOcean.Damage)
+	}
+}
+
+func TestLargeOcean(t *testing.T) {
+	rules := board.DefaultRuleset().WithSize(12, 12)
+	carrier, _ := board.SquareByString("L8")
+	piece, _ := rules.NewPiece(board.Ship("Carrier"), carrier, false)
+	testOcean, err := NewOceanWithRules(rules, []board.Piece{piece})
+	if err != nil {
+		t.Errorf("NewOceanWithRules returned an unexpected error on a 12x12 board: %v", err)
+	}
+
+	for coords, expected := range map[string]string{"L12": "Hit", "K12": "Miss"} {
+		square, _ := board.SquareByString(coords)
+		if result, err := testOcean.Fire(square); err != nil || result != expected {
+			t.Errorf("Fire did not return %v at %v, got %v: %v", expected, coords, result, err)
+		}
+	}
+
+	square, _ := board.SquareByString("M1")
+	if result, err := testOcean.Fire(square); err == nil {
+		t.Errorf("Fire did not error on a shot off the 12x12 board, returned %v", result)
+	}
+}
+
+func TestDefeated(t *testing.T) {
+	testOcean, _ := NewOcean(exampleFleet)
+
+	for _, piece := range exampleFleet {
+		if testOcean.Defeated() {
+			t.Errorf("Defeated returned true with %v ships afloat", testOcean.Afloat)
+		}
+		for _, square := range piece.Coords {
+			testOcean.Fire(square)
+		}
+	}
+
+	if !testOcean.Defeated() {
+		t.Errorf("Defeated returned false after sinking every ship: %v", testOcean.Damage)
+	}
+}
+
+var exampleRussianFleet = []board.Piece{
+	{Type: board.Ship("Cruiser"), Coords: []board.Square{{Letter: 0, Number: 0}, {Letter: 1, Number: 0}, {Letter: 2, Number: 0}}},
+	{Type: board.Ship("Cruiser"), Coords: []board.Square{{Letter: 0, Number: 2}, {Letter: 1, Number: 2}, {Letter: 2, Number: 2}}},
+	{Type: board.Ship("Submarine"), Coords: []board.Square{{Letter: 9, Number: 9}}},
+}
+
+func TestRussianOcean(t *testing.T) {
+	russian, _ := board.RulesetByName("Russian")
+	testOcean, err := NewOceanWithRules(russian, exampleRussianFleet)
+	if err != nil {
+		t.Errorf("NewOceanWithRules returned an unexpected error: %v", err)
+	}
+
+	for _, coords := range []string{"A1", "B1", "J10"} {
+		square, _ := board.SquareByString(coords)
+		testOcean.Fire(square)
+	}
+	square, _ := board.SquareByString("C1")
+	if result, _ := testOcean.Fire(square); result != "Sunk" {
+		t.Errorf("Fire did not announce an unnamed sink under Russian rules, got %v", result)
+	}
+
+	if testOcean.IsSunk(board.Ship("Cruiser")) || !testOcean.IsSunk(board.Ship("Submarine")) {
+		t.Errorf("IsSunk did not report the second Cruiser afloat and the Submarine sunk: %v", testOcean.Damage)
+	}
+}
+
+func TestBadRussianOcean(t *testing.T) {
+	russian, _ := board.RulesetByName("Russian")
+	touching := []board.Piece{
+		exampleRussianFleet[0],
+		{Type: board.Ship("Submarine"), Coords: []board.Square{{Letter: 3, Number: 1}}},
+	}
+	if _, err := NewOceanWithRules(russian, touching); err == nil {
+		t.Errorf("NewOceanWithRules did not error with ships touching diagonally")
+	}
+
+	if _, err := NewOceanWithRules(russian, exampleFleet); err == nil {
+		t.Errorf("NewOceanWithRules did not error with a Carrier outside the Russian fleet")
+	}
+}
@@ -0,0 +1,224 @@
+package gobat
+
+import (
+	"errors"
+	"fmt"
+	"strings"
+
+	"github.com/eaglerock1337/gobat/pkg/board"
+	"github.com/jroimartin/gocui"
+)
+
+const (
+	commandY     = 3 // The height of the command line below the grid
+	commandUsage = ": - Type a shot, e.g. B7 hit, C3 miss or D5 sunk cruiser"
+)
+
+// commandResults are the results a shot can be given on the command line
+var commandResults = []string{"hit", "miss", "sunk"}
+
+// openCommand selects the command line of the grid screen, unless a prompt is open
+func (m *viewModel) openCommand() {
+	if m.screen != "grid" || m.current == "prompt" || m.current == "command" {
+		return
+	}
+	m.commandReturn = m.current
+	m.current = "command"
+}
+
+// closeCommand returns from the command line to the view it was opened from
+func (m *viewModel) closeCommand() {
+	if m.current == "command" {
+		m.current = m.commandReturn
+	}
+	m.commandError, m.commandHint = "", ""
+}
+
+// typeCommand types a character at the end of the command line
+func (m *viewModel) typeCommand(ch rune) {
+	m.command += string(ch)
+	m.commandError, m.commandHint = "", ""
+}
+
+// backspaceCommand deletes the last character of the command line
+func (m *viewModel) backspaceCommand() {
+	if m.command != "" {
+		m.command = m.command[:len(m.command)-1]
+	}
+	m.commandError, m.commandHint = "", ""
+}
+
+// completeCommand completes the word being typed on the command line, which
+// is a result after the square, or a ship still afloat after a sunk result.
+// When more than one word fits, it completes as much as they share and lists them.
+func (m *viewModel) completeCommand() {
+	words := strings.Fields(strings.ToLower(m.command))
+	partial := ""
+	if len(words) > 0 && !strings.HasSuffix(m.command, " ") {
+		partial = words[len(words)-1]
+		words = words[:len(words)-1]
+	}
+
+	var options []string
+	switch {
+	case len(words) == 1:
+		options = commandResults
+	case len(words) == 2 && words[1] == "sunk":
+		for _, ship := range m.afloatTypes() {
+			options = append(options, strings.ToLower(ship))
+		}
+	}
+
+	var matches []string
+	for _, option := range options {
+		if strings.HasPrefix(option, partial) {
+			matches = append(matches, option)
+		}
+	}
+	if len(matches) == 0 {
+		m.commandHint = ""
+		return
+	}
+
+	completion := matches[0]
+	for _, match := range matches[1:] {
+		for !strings.HasPrefix(match, completion) {
+			completion = completion[:len(completion)-1]
+		}
+	}
+	m.command = m.command[:len(m.command)-len(partial)] + completion
+	m.commandHint = ""
+	if len(matches) > 1 {
+		m.commandHint = strings.Join(matches, " ")
+	} else if completion == "sunk" {
+		m.command += " "
+	}
+}
+
+// parseCommand parses a command such as "B7 hit", "C3 miss" or "D5 sunk cruiser"
+// into the square shot and its result for the hunter. A sunk result without
+// a ship leaves the hunter to work out which ship was sunk.
+func (m *viewModel) parseCommand(command string) (board.Square, string, error) {
+	words := strings.Fields(strings.ToLower(command))
+	if len(words) < 2 || len(words) > 3 {
+		return board.Square{}, "", errors.New("Type a square and its result, e.g. B7 hit")
+	}
+
+	square, err := m.hunter.Board.SquareByString(words[0])
+	if err != nil {
+		return board.Square{}, "", err
+	}
+
+	switch words[1] {
+	case "hit", "miss":
+		if len(words) == 3 {
+			return board.Square{}, "", fmt.Errorf("Only a sunk ship can be named, not a %s", words[1])
+		}
+		return square, strings.ToUpper(words[1][:1]) + words[1][1:], nil
+	case "sunk":
+		if len(words) == 2 {
+			return square, "Sunk", nil
+		}
+		ship, err := board.NewShip(strings.ToUpper(words[2][:1]) + words[2][1:])
+		if err != nil {
+			return board.Square{}, "", fmt.Errorf("%s is not a type of ship", words[2])
+		}
+		return square, ship.GetType(), nil
+	}
+	return board.Square{}, "", fmt.Errorf("%s is not a result, try hit, miss or sunk", words[1])
+}
+
+// runCommand plays the shot typed on the command line, keeping the command
+// and showing why if it cannot be played
+func (m *viewModel) runCommand() {
+	if m.salvoMode() {
+		m.commandError = "Salvo results are chosen in the select view"
+		return
+	}
+
+	square, result, err := m.parseCommand(m.command)
+	if err == nil && !m.hunter.Board.IsEmpty(square) {
+		err = fmt.Errorf("%s has already been played", square.PrintSquare())
+	}
+	if err == nil {
+		err = m.hunter.Turn(square, result)
+	}
+	if err != nil {
+		m.commandError = err.Error()
+		return
+	}
+
+	m.command = ""
+	m.commandError, m.commandHint = "", ""
+	m.turnPlayed()
+}
+
+// editCommand is the gocui editor of the command line, passing the keys
+// typed that are not keybindings to the view-model
+func (a *App) editCommand(v *gocui.View, key gocui.Key, ch rune, mod gocui.Modifier) {
+	switch {
+	case key == gocui.KeyTab:
+		a.completeCommand()
+	case key == gocui.KeyBackspace || key == gocui.KeyBackspace2:
+		a.backspaceCommand()
+	case key == gocui.KeySpace:
+		a.typeCommand(' ')
+	case ch != 0 && mod == gocui.ModNone:
+		a.typeCommand(ch)
+	}
+}
+
+// typed returns a keybinding handler for a character, which is typed on the
+// command line while it is selected, is left to the prompt while it has an
+// option labelled with the character, or otherwise runs the given handler
+func (a *App) typed(ch rune, handler func(*gocui.Gui, *gocui.View) error) func(*gocui.Gui, *gocui.View) error {
+	return func(g *gocui.Gui, v *gocui.View) error {
+		if a.current == "command" {
+			a.typeCommand(ch)
+			return nil
+		}
+		// the prompt's own binding chooses the option labelled with the letter
+		if _, ok := a.promptKeyOption(ch); ok {
+			return nil
+		}
+		return handler(g, v)
+	}
+}
+
+// showCommandView shows the command line along the bottom of the grid screen
+func (a *App) showCommandView(g *gocui.Gui) error {
+	maxX, _ := g.Size()
+	top := a.commandTop()
+
+	v, err := g.SetView("command", 0, top, maxX-1, top+commandY-1)
+	if err != nil {
+		if err != gocui.ErrUnknownView {
+			return err
+		}
+		v.Title = "Command"
+		v.Editable = true
+		v.Editor = gocui.EditorFunc(a.editCommand)
+	}
+	// the command line changes between key presses, so it is filled in straight away
+	a.refreshCommandView(v)
+
+	g.Cursor = a.current == "command"
+	return nil
+}
+
+// refreshCommandView refreshes the command line, showing any error in red
+func (a *App) refreshCommandView(v *gocui.View) {
+	v.Clear()
+	if a.current != "command" && a.command == "" {
+		fmt.Fprint(v, commandUsage)
+		return
+	}
+
+	fmt.Fprintf(v, "> %s", a.command)
+	v.SetCursor(len(a.command)+2, 0)
+	if a.commandError != "" {
+		fmt.Fprintf(v, "  \x1b[31m%s\x1b[0m", a.commandError)
+	} else if a.commandHint != "" {
+		fmt.Fprintf(v, "  %s", a.commandHint)
+	}
+}
@@ -0,0 +1,162 @@
+package gobat
+
+import (
+	"testing"
+
+	"github.com/eaglerock1337/gobat/pkg/board"
+)
+
+// typeLine types the given text on the command line
+func typeLine(m *viewModel, text string) {
+	for _, ch := range text {
+		m.typeCommand(ch)
+	}
+}
+
+var parseCommandTests = []struct {
+	command string
+	square  string
+	result  string
+	valid   bool
+}{
+	{"B7 hit", "B7", "Hit", true},
+	{"c3 MISS", "C3", "Miss", true},
+	{"  D5   sunk  cruiser ", "D5", "Cruiser", true},
+	{"j10 sunk", "J10", "Sunk", true},
+	{"A1 sunk Carrier", "A1", "Carrier", true},
+	{"B7", "", "", false},
+	{"B7 hit cruiser", "", "", false},
+	{"K1 hit", "", "", false},
+	{"B7 splash", "", "", false},
+	{"B7 sunk frigate", "", "", false},
+	{"B7 sunk cruiser now", "", "", false},
+}
+
+func TestParseCommand(t *testing.T) {
+	m := gridModel(board.DefaultRuleset())
+	for _, test := range parseCommandTests {
+		square, result, err := m.parseCommand(test.command)
+		if !test.valid {
+			if err == nil {
+				t.Errorf("parseCommand did not reject %q, got %v %v", test.command, square.PrintSquare(), result)
+			}
+			continue
+		}
+		if err != nil || square.PrintSquare() != test.square || result != test.result {
+			t.Errorf("parseCommand did not parse %q as %v %v, got %v %v (%v)",
+				test.command, test.square, test.result, square.PrintSquare(), result, err)
+		}
+	}
+}
+
+func TestOpenCommand(t *testing.T) {
+	m := testModel()
+	m.openCommand()
+	if m.current != "menu" {
+		t.Errorf("openCommand did open the command line from the menu")
+	}
+
+	m.switchToGrid()
+	m.current = "C4"
+	m.openCommand()
+	if m.current != "command" {
+		t.Errorf("openCommand did not select the command line, got %v", m.current)
+	}
+
+	m.cursorDown()
+	m.cursorRight()
+	if m.current != "command" {
+		t.Errorf("the cursor did not stay on the command line, got %v", m.current)
+	}
+
+	m.escape()
+	if m.current != "C4" {
+		t.Errorf("escape did not return from the command line, got %v", m.current)
+	}
+
+	m.openPrompt("Test", []string{"A", "B"})
+	m.openCommand()
+	if m.current != "prompt" {
+		t.Errorf("openCommand did open the command line over the prompt")
+	}
+}
+
+func TestRunCommand(t *testing.T) {
+	m := gridModel(board.DefaultRuleset())
+	m.openCommand()
+	typeLine(m, "B7 hit")
+	m.enter()
+
+	square, _ := board.SquareByString("B7")
+	if m.hunter.Turns != 1 || !m.hunter.Board.IsUnsunk(square) {
+		t.Errorf("runCommand did not play a hit at B7, got %v turns", m.hunter.Turns)
+	}
+	if m.command != "" || m.current != "command" {
+		t.Errorf("runCommand did not clear the command line for the next shot, got %q", m.command)
+	}
+
+	typeLine(m, "B7 miss")
+	m.enter()
+	if m.hunter.Turns != 1 || m.commandError == "" || m.command != "B7 miss" {
+		t.Errorf("runCommand did not keep and explain a command that cannot be played, got %q", m.commandError)
+	}
+
+	m.backspaceCommand()
+	if m.command != "B7 mis" || m.commandError != "" {
+		t.Errorf("backspaceCommand did not delete a character and the error, got %q", m.command)
+	}
+}
+
+func TestRunCommandSalvo(t *testing.T) {
+	m := testModel()
+	m.toggleSalvoMode()
+	m.switchToGrid()
+	m.openCommand()
+	typeLine(m, "B7 hit")
+	m.enter()
+
+	if m.hunter.Turns != 0 || m.commandError == "" {
+		t.Errorf("runCommand did play a single shot in salvo mode")
+	}
+}
+
+var completeCommandTests = []struct {
+	command  string
+	expected string
+	hint     string
+}{
+	{"B7 h", "B7 hit", ""},
+	{"B7 s", "B7 sunk ", ""},
+	{"B7 sunk c", "B7 sunk c", "carrier cruiser"},
+	{"B7 sunk cr", "B7 sunk cruiser", ""},
+	{"B7 sunk ", "B7 sunk ", "carrier battleship cruiser submarine destroyer"},
+	{"B7 x", "B7 x", ""},
+	{"B7", "B7", ""},
+	{"B7 hit cr", "B7 hit cr", ""},
+}
+
+func TestCompleteCommand(t *testing.T) {
+	for _, test := range completeCommandTests {
+		m := gridModel(board.DefaultRuleset())
+		m.openCommand()
+		typeLine(m, test.command)
+		m.completeCommand()
+		if m.command != test.expected || m.commandHint != test.hint {
+			t.Errorf("completeCommand did not complete %q to %q with hint %q, got %q with %q",
+				test.command, test.expected, test.hint, m.command, m.commandHint)
+		}
+	}
+}
+
+func TestCompleteCommandAfloat(t *testing.T) {
+	m := gridModel(board.DefaultRuleset())
+	playSquare(m, "A1", "H - Hit")
+	playSquare(m, "A2", "S - Sunk", "Destroyer")
+
+	m.openCommand()
+	typeLine(m, "C3 sunk d")
+	m.completeCommand()
+	if m.command != "C3 sunk d" {
+		t.Errorf("completeCommand did complete a ship already sunk, got %q", m.command)
+	}
+}
@@ -2,129 +2,91 @@ package gobat
 
 import (
 	"github.com/eaglerock1337/gobat/pkg/board"
-	"github.com/jroimartin/gocui"
 )
 
-// cursorDown handles the gocui cursor down keybind
-func cursorDown(g *gocui.Gui, v *gocui.View) error {
-	switch currentView {
-	case "error", "grid", "stats":
-		return nil
+// cursorDown handles the cursor down keybind
+func (m *viewModel) cursorDown() {
+	switch m.current {
+	case "command", "error", "grid", "stats":
 	case "menu", "menubg":
-		currentView = "menu"
-		g.SetCurrentView(currentView)
-		menuSelection++
-		if menuSelection > len(menuControls)-1 {
-			menuSelection = len(menuControls) - 1
+		m.current = "menu"
+		if m.menuSelection < len(menuControls)-1 {
+			m.menuSelection++
 		}
-		refreshMenuView(g, g.CurrentView())
 	case "select":
-		gridSelection++
-		if gridSelection > len(theHunter.Shots)+len(gridControls)-1 {
-			gridSelection = len(theHunter.Shots) + len(gridControls) - 1
+		if m.gridSelection < len(m.selectOptions())-1 {
+			m.gridSelection++
 		}
-		refreshSelectView(v)
-	default:
-		curSquare, _ := board.SquareByString(currentView)
-		newSquare, err := board.SquareByValue(curSquare.Letter, curSquare.Number+1)
-		if err != nil {
-			return nil
-		}
-		currentView = newSquare.PrintSquare()
-		if _, err := g.SetCurrentView(currentView); err != nil {
-			return err
+	case "prompt":
+		if m.promptSelection < len(m.promptOptions)-1 {
+			m.promptSelection++
 		}
+	default:
+		m.moveSquare(0, 1)
 	}
-	return nil
 }
 
-// cursorUp handles the gocui cursor down keybind
-func cursorUp(g *gocui.Gui, v *gocui.View) error {
-	switch currentView {
-	case "error", "grid", "stats":
-		return nil
+// cursorUp handles the cursor up keybind
+func (m *viewModel) cursorUp() {
+	switch m.current {
+	case "command", "error", "grid", "stats":
 	case "menu", "menubg":
-		currentView = "menu"
-		g.SetCurrentView(currentView)
-		menuSelection--
-		if menuSelection < 0 {
-			menuSelection = 0
+		m.current = "menu"
+		if m.menuSelection > 0 {
+			m.menuSelection--
 		}
-		refreshMenuView(g, g.CurrentView())
 	case "select":
-		gridSelection--
-		if gridSelection < 0 {
-			gridSelection = 0
+		if m.gridSelection > 0 {
+			m.gridSelection--
 		}
-		refreshSelectView(v)
-	default:
-		curSquare, _ := board.SquareByString(currentView)
-		newSquare, err := board.SquareByValue(curSquare.Letter, curSquare.Number-1)
-		if err != nil {
-			return nil
-		}
-		currentView = newSquare.PrintSquare()
-		if _, err := g.SetCurrentView(currentView); err != nil {
-			return err
+	case "prompt":
+		if m.promptSelection > 0 {
+			m.promptSelection--
 		}
+	default:
+		m.moveSquare(0, -1)
 	}
-	return nil
 }
 
-// cursorLeft handles the gocui cursor left keybind
-func cursorLeft(g *gocui.Gui, v *gocui.View) error {
-	switch currentView {
+// cursorLeft handles the cursor left keybind, moving from the select view to
+// the last square of the grid
+func (m *viewModel) cursorLeft() {
+	switch m.current {
 	case "select":
-		currentView = "J10"
-		if _, err := g.SetCurrentView("J10"); err != nil {
-			return err
-		}
-		refreshSelectView(v)
-	case "error", "grid", "menu", "menubg", "stats":
-		return nil
+		last := board.Square{Letter: m.hunter.Board.Width() - 1, Number: m.hunter.Board.Height() - 1}
+		m.current = last.PrintSquare()
+	case "command", "error", "grid", "menu", "menubg", "prompt", "stats":
 	default:
-		curSquare, _ := board.SquareByString(currentView)
-		if curSquare.Letter > 0 {
-			newSquare, err := board.SquareByValue(curSquare.Letter-1, curSquare.Number)
-			if err != nil {
-				return err
-			}
-			currentView = newSquare.PrintSquare()
-			if _, err := g.SetCurrentView(currentView); err != nil {
-				return err
-			}
-		}
+		m.moveSquare(-1, 0)
 	}
-	refreshSquareView(g.CurrentView())
-	return nil
 }
 
-// cursorRight handles the gocui cursor Right keybind
-func cursorRight(g *gocui.Gui, v *gocui.View) error {
-	switch currentView {
-	case "error", "grid", "menu", "menubg", "select", "stats":
-		return nil
+// cursorRight handles the cursor right keybind, moving from the last column
+// of the grid to the select view
+func (m *viewModel) cursorRight() {
+	switch m.current {
+	case "command", "error", "grid", "menu", "menubg", "prompt", "select", "stats":
 	default:
-		curSquare, _ := board.SquareByString(currentView)
-		if curSquare.Letter == 9 {
-			currentView = "select"
-			gridSelection = 0
-		} else {
-			newSquare, err := board.SquareByValue(curSquare.Letter+1, curSquare.Number)
-			if err != nil {
-				return err
-			}
-			currentView = newSquare.PrintSquare()
-		}
-		if _, err := g.SetCurrentView(currentView); err != nil {
-			return err
-		}
-		refreshSquareView(v)
-		if currentView == "select" {
-			refreshSelectView(g.CurrentView())
-		} else {
-			refreshSquareView(g.CurrentView())
+		square, err := board.SquareByString(m.current)
+		if err == nil && square.Letter == m.hunter.Board.Width()-1 {
+			m.current = "select"
+			m.gridSelection = 0
+			return
 		}
+		m.moveSquare(1, 0)
+	}
+}
+
+// moveSquare selects the square the given distance from the selected square,
+// staying put at the edges of the board
+func (m *viewModel) moveSquare(letters, numbers int) {
+	square, err := board.SquareByString(m.current)
+	if err != nil {
+		return
+	}
+	next, err := m.hunter.Board.Square(square.Letter+letters, square.Number+numbers)
+	if err != nil {
+		return
 	}
-	return nil
+	m.current = next.PrintSquare()
 }
@@ -0,0 +1,123 @@
+package gobat
+
+import (
+	"testing"
+)
+
+var cursorTests = []struct {
+	start                 string
+	up, down, left, right string
+}{
+	{"A1", "A1", "A2", "A1", "B1"},
+	{"E5", "E4", "E6", "D5", "F5"},
+	{"J10", "J9", "J10", "I10", "select"},
+	{"J1", "J1", "J2", "I1", "select"},
+	{"A10", "A9", "A10", "A10", "B10"},
+	{"select", "select", "select", "J10", "select"},
+	{"stats", "stats", "stats", "stats", "stats"},
+	{"grid", "grid", "grid", "grid", "grid"},
+}
+
+// cursorFrom returns the view selected after moving the cursor from the given view
+func cursorFrom(start string, move func(*viewModel)) string {
+	m := testModel()
+	m.screen = "grid"
+	m.current = start
+	move(m)
+	return m.current
+}
+
+func TestCursorSquares(t *testing.T) {
+	for _, test := range cursorTests {
+		if got := cursorFrom(test.start, (*viewModel).cursorUp); got != test.up {
+			t.Errorf("cursorUp from %v did not select %v, got %v", test.start, test.up, got)
+		}
+		if got := cursorFrom(test.start, (*viewModel).cursorDown); got != test.down {
+			t.Errorf("cursorDown from %v did not select %v, got %v", test.start, test.down, got)
+		}
+		if got := cursorFrom(test.start, (*viewModel).cursorLeft); got != test.left {
+			t.Errorf("cursorLeft from %v did not select %v, got %v", test.start, test.left, got)
+		}
+		if got := cursorFrom(test.start, (*viewModel).cursorRight); got != test.right {
+			t.Errorf("cursorRight from %v did not select %v, got %v", test.start, test.right, got)
+		}
+	}
+}
+
+func TestCursorRightSelect(t *testing.T) {
+	m := testModel()
+	m.current = "J3"
+	m.gridSelection = 2
+	m.cursorRight()
+	if m.gridSelection != 0 {
+		t.Errorf("cursorRight into the select view did not select the top shot, got %v", m.gridSelection)
+	}
+}
+
+func TestCursorMenu(t *testing.T) {
+	m := testModel()
+	m.cursorUp()
+	if m.menuSelection != 0 {
+		t.Errorf("cursorUp did not stop at the top of the menu, got %v", m.menuSelection)
+	}
+
+	for range menuControls {
+		m.cursorDown()
+	}
+	if m.menuSelection != len(menuControls)-1 {
+		t.Errorf("cursorDown did not stop at the bottom of the menu, got %v", m.menuSelection)
+	}
+
+	m.current = "menubg"
+	m.cursorUp()
+	if m.current != "menu" || m.menuSelection != len(menuControls)-2 {
+		t.Errorf("cursorUp from the background did not move the menu selection, got %v at %v", m.current, m.menuSelection)
+	}
+}
+
+func TestCursorSelect(t *testing.T) {
+	m := testModel()
+	m.switchToGrid()
+	options := m.selectOptions()
+
+	for range options {
+		m.cursorDown()
+	}
+	if m.gridSelection != len(options)-1 {
+		t.Errorf("cursorDown did not stop at the bottom of the select view, got %v", m.gridSelection)
+	}
+
+	for range options {
+		m.cursorUp()
+	}
+	if m.gridSelection != 0 {
+		t.Errorf("cursorUp did not stop at the top of the select view, got %v", m.gridSelection)
+	}
+}
+
+func TestCursorPrompt(t *testing.T) {
+	m := testModel()
+	m.switchToGrid()
+	m.openPrompt("Test", []string{"A", "B", "C"})
+
+	m.cursorLeft()
+	m.cursorRight()
+	if m.current != "prompt" {
+		t.Errorf("cursorLeft and cursorRight did not stay in the prompt, got %v", m.current)
+	}
+
+	for i := 0; i < 5; i++ {
+		m.cursorDown()
+	}
+	if m.promptSelection != 2 {
+		t.Errorf("cursorDown did not stop at the last prompt option, got %v", m.promptSelection)
+	}
+	if m.gridSelection != 0 {
+		t.Errorf("cursorDown in the prompt did not leave the select view alone, got %v", m.gridSelection)
+	}
+
+	m.cursorUp()
+	if m.promptSelection != 1 {
+		t.Errorf("cursorUp did not move up the prompt, got %v", m.promptSelection)
+	}
+}
@@ -2,131 +2,267 @@
 Package gobat is responsible for creating and managing the console display for
 the gobat CLI application. It is responsible for creating an ncurses-based window
 that will be used for displaying the game board and top moves.
+
+The grid has one view per square of the board, so the window needed grows with the
+size of the board being played.
+
+An App keeps everything shown on the screen in a view-model, which the keybindings
+update without any gocui calls, and draws the view-model on every frame.
 */
 
 package gobat
 
 import (
-	"log"
-
+	"github.com/eaglerock1337/gobat/pkg/board"
 	"github.com/eaglerock1337/gobat/pkg/hunter"
 	"github.com/jroimartin/gocui"
 )
 
 const (
-	minX  = 71
-	minY  = 31
-	gridX = 50
+	squareX = 5  // The width of each square view in the grid
+	squareY = 3  // The height of each square view in the grid
+	sideX   = 21 // The width of the side views next to the grid
+	sideY   = 31 // The least height needed by the side views
 )
 
-var (
-	theHunter   *hunter.Hunter
-	currentView = "menu"
-)
+// viewModel holds the state of every screen of the terminal application,
+// separate from the gocui views it is drawn to
+type viewModel struct {
+	hunter  hunter.Hunter // The game being played
+	screen  string        // The screen being shown, either "menu" or "grid"
+	current string        // The name of the selected view
+	maxX    int           // The width of the terminal
+	maxY    int           // The height of the terminal
+	quit    bool          // Whether the application has been asked to quit
 
-// NewTerminal instantiates a gobat terminal screen
-func NewTerminal() *gocui.Gui {
-	screen, err := gocui.NewGui(gocui.OutputNormal)
-	if err != nil {
-		log.Panicln(err)
+	menuSelection int             // The selected line of the menu
+	menuMessage   string          // The result of the last menu action
+	menuRules     []board.Ruleset // The rulesets offered for a new game
+
+	gridSelection  int          // The selected line of the select view
+	gridMessage    string       // The result of the last turn played
+	selectedSquare board.Square // The square being asked about in the prompt
+
+	promptName      string   // The title of the prompt
+	promptOptions   []string // The options of the prompt, nil when closed
+	promptSelection int      // The selected option of the prompt
+	promptReturn    string   // The view selected when the prompt was opened
+
+	salvoShots   []board.Square // The recommended shots of the next salvo
+	salvoResults []string       // The chosen result of each salvo shot
+
+	command       string // The shot being typed on the command line
+	commandError  string // Why the last command could not be played
+	commandHint   string // The words the command line could complete to
+	commandReturn string // The view selected when the command line was opened
+}
+
+// newViewModel returns a view-model on the main menu for a new game of the given rules
+func newViewModel(rules board.Ruleset) *viewModel {
+	m := &viewModel{screen: "menu", current: "menu"}
+	m.newGame(rules)
+	return m
+}
+
+// resize records the size of the terminal
+func (m *viewModel) resize(maxX, maxY int) {
+	m.maxX, m.maxY = maxX, maxY
+}
+
+// gridX returns the width of the grid for the current board.
+func (m *viewModel) gridX() int {
+	return m.hunter.Board.Width() * squareX
+}
+
+// gridY returns the height of the grid for the current board.
+func (m *viewModel) gridY() int {
+	return m.hunter.Board.Height() * squareY
+}
+
+// minX returns the narrowest screen that fits the grid and side views.
+func (m *viewModel) minX() int {
+	return m.gridX() + sideX
+}
+
+// commandTop returns the row of the command line, below the grid and side views.
+func (m *viewModel) commandTop() int {
+	if m.gridY()+1 > sideY {
+		return m.gridY() + 1
 	}
+	return sideY
+}
 
-	screen.SetManagerFunc(menuLayout)
-	screen.SetCurrentView(currentView)
-	screen.Mouse = true
+// minY returns the shortest screen that fits the grid, side views and command line.
+func (m *viewModel) minY() int {
+	return m.commandTop() + commandY
+}
 
-	if err := setKeyBindings(screen); err != nil {
-		log.Panicln(err)
+// fits returns whether the terminal is large enough for the grid screen
+func (m *viewModel) fits() bool {
+	return m.minX() <= m.maxX && m.minY() <= m.maxY
+}
+
+// enter handles the enter key in whichever view is selected
+func (m *viewModel) enter() {
+	switch m.current {
+	case "menu", "menubg":
+		m.menuEnter()
+	case "prompt":
+		m.promptEnter()
+	case "command":
+		m.runCommand()
+	default:
+		m.gridEnter()
+	}
+}
+
+// click selects the clicked view, unless it cannot be selected or a prompt is open
+func (m *viewModel) click(name string) {
+	switch name {
+	case "error", "grid", "menubg", "prompt", "stats":
+		return
 	}
+	if m.current == "prompt" {
+		return
+	}
+	if name == "command" {
+		m.openCommand()
+		return
+	}
+	m.current = name
+}
 
-	hunt := hunter.NewHunter()
-	hunt.Seek()
-	theHunter = &hunt
+// App is the gobat terminal application, which owns the game being played,
+// the state of every view and the gocui keybindings that change it
+type App struct {
+	viewModel
+	shown string // The screen whose layout gocui is drawing
+}
 
-	return screen
+// NewApp instantiates a gobat terminal application for the default rules
+func NewApp() *App {
+	return NewAppWithRules(board.DefaultRuleset())
 }
 
-// Run starts the main event loop of the application
-func Run(g *gocui.Gui) {
+// NewAppWithRules instantiates a gobat terminal application for the given rules
+func NewAppWithRules(rules board.Ruleset) *App {
+	return &App{viewModel: *newViewModel(rules)}
+}
+
+// Run opens the terminal screen and runs the main event loop of the
+// application until it quits
+func (a *App) Run() error {
+	g, err := gocui.NewGui(gocui.Output256)
+	if err != nil {
+		return err
+	}
 	defer g.Close()
 
+	g.Mouse = true
+	// without this, escape is only read as the start of an alt key combination
+	g.InputEsc = true
+	if err := a.showScreen(g); err != nil {
+		return err
+	}
+
 	if err := g.MainLoop(); err != nil && err != gocui.ErrQuit {
-		log.Panicln(err)
+		return err
 	}
+	return nil
 }
 
-// quit terminates the screen and event loop
-func quit(g *gocui.Gui, v *gocui.View) error {
-	return gocui.ErrQuit
+// showScreen sets the gocui layout for the screen of the view-model, which
+// deletes every view and keybinding, so the keybindings are set again
+func (a *App) showScreen(g *gocui.Gui) error {
+	a.shown = a.screen
+	if a.screen == "grid" {
+		g.SetManagerFunc(a.gridLayout)
+	} else {
+		g.SetManagerFunc(a.menuLayout)
+	}
+	return a.setKeyBindings(g)
 }
 
-// enterKey handles enter key input
-func enterKey(g *gocui.Gui, v *gocui.View) error {
-	if v == nil {
-		g.SetCurrentView(currentView)
-		v = g.CurrentView()
-	}
-	switch v.Name() {
-	case "menu", "menubg":
-		if err := menuEnterKeySelection(g, v); err != nil {
-			return err
-		}
-	case "prompt":
-		if err := promptEnterKeySelection(g, v); err != nil {
-			return err
+// handle returns a gocui keybinding handler that applies the given action to
+// the view-model, then switches screens or quits if the action asked to
+func (a *App) handle(action func()) func(*gocui.Gui, *gocui.View) error {
+	return func(g *gocui.Gui, v *gocui.View) error {
+		a.resize(g.Size())
+		action()
+		if a.quit {
+			return gocui.ErrQuit
 		}
-	default:
-		if err := gridEnterKeySelection(g, v); err != nil {
-			return err
+		if a.screen != a.shown {
+			return a.showScreen(g)
 		}
+		return nil
 	}
-	return nil
 }
 
 // mouseClick handles mouse click input
-func mouseClick(g *gocui.Gui, v *gocui.View) error {
-	switch v.Name() {
-	case "error", "grid", "menubg", "stats":
+func (a *App) mouseClick(g *gocui.Gui, v *gocui.View) error {
+	if v == nil {
 		return nil
-	case "menu":
-		menuMouseClickSelection(g, v)
-	default:
-		gridMouseClickSelection(g, v)
 	}
-	return nil
+	return a.handle(func() { a.click(v.Name()) })(g, v)
 }
 
 // setKeyBindings sets all gocui keybindings
-func setKeyBindings(g *gocui.Gui) error {
+func (a *App) setKeyBindings(g *gocui.Gui) error {
+	quit := func(g *gocui.Gui, v *gocui.View) error {
+		return gocui.ErrQuit
+	}
 	if err := g.SetKeybinding("", gocui.KeyCtrlC, gocui.ModNone, quit); err != nil {
 		return err
 	}
-	if err := g.SetKeybinding("", 'q', gocui.ModNone, quit); err != nil {
+	if err := g.SetKeybinding("", 'q', gocui.ModNone, a.typed('q', quit)); err != nil {
+		return err
+	}
+	if err := g.SetKeybinding("", 'm', gocui.ModNone, a.typed('m', a.handle(a.switchToMenu))); err != nil {
 		return err
 	}
-	if err := g.SetKeybinding("", 'm', gocui.ModNone, switchToMenu); err != nil {
+	if err := g.SetKeybinding("", 'g', gocui.ModNone, a.typed('g', a.handle(a.switchToGrid))); err != nil {
 		return err
 	}
-	if err := g.SetKeybinding("", 'g', gocui.ModNone, switchToGrid); err != nil {
+	if err := g.SetKeybinding("", 'u', gocui.ModNone, a.typed('u', a.handle(a.undoTurn))); err != nil {
 		return err
 	}
-	if err := g.SetKeybinding("", gocui.KeyArrowDown, gocui.ModNone, cursorDown); err != nil {
+	if err := g.SetKeybinding("", ':', gocui.ModNone, a.typed(':', a.handle(a.openCommand))); err != nil {
 		return err
 	}
-	if err := g.SetKeybinding("", gocui.KeyArrowUp, gocui.ModNone, cursorUp); err != nil {
+	if err := g.SetKeybinding("", gocui.KeyCtrlR, gocui.ModNone, a.handle(a.redoTurn)); err != nil {
 		return err
 	}
-	if err := g.SetKeybinding("", gocui.KeyArrowLeft, gocui.ModNone, cursorLeft); err != nil {
+	if err := g.SetKeybinding("", gocui.KeyArrowDown, gocui.ModNone, a.handle(a.cursorDown)); err != nil {
 		return err
 	}
-	if err := g.SetKeybinding("", gocui.KeyArrowRight, gocui.ModNone, cursorRight); err != nil {
+	if err := g.SetKeybinding("", gocui.KeyArrowUp, gocui.ModNone, a.handle(a.cursorUp)); err != nil {
 		return err
 	}
-	if err := g.SetKeybinding("", gocui.KeyEnter, gocui.ModNone, enterKey); err != nil {
+	if err := g.SetKeybinding("", gocui.KeyArrowLeft, gocui.ModNone, a.handle(a.cursorLeft)); err != nil {
 		return err
 	}
-	if err := g.SetKeybinding("", gocui.MouseLeft, gocui.ModNone, mouseClick); err != nil {
+	if err := g.SetKeybinding("", gocui.KeyArrowRight, gocui.ModNone, a.handle(a.cursorRight)); err != nil {
 		return err
 	}
+	if err := g.SetKeybinding("", gocui.KeyEnter, gocui.ModNone, a.handle(a.enter)); err != nil {
+		return err
+	}
+	if err := g.SetKeybinding("", gocui.KeyEsc, gocui.ModNone, a.handle(a.escape)); err != nil {
+		return err
+	}
+	if err := g.SetKeybinding("", gocui.MouseLeft, gocui.ModNone, a.mouseClick); err != nil {
+		return err
+	}
+
+	// letters choose the prompt option labelled with them, e.g. M for Miss,
+	// taking precedence over the letters bound above
+	for ch := 'a'; ch <= 'z'; ch++ {
+		for _, key := range []rune{ch, ch - 'a' + 'A'} {
+			if err := g.SetKeybinding("prompt", key, gocui.ModNone, a.handle(func() { a.promptKey(ch) })); err != nil {
+				return err
+			}
+		}
+	}
 	return nil
 }
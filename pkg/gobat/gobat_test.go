@@ -0,0 +1,104 @@
+package gobat
+
+import (
+	"testing"
+
+	"github.com/eaglerock1337/gobat/pkg/board"
+)
+
+// testModel returns a view-model for the default rules on a terminal large
+// enough for the grid screen
+func testModel() *viewModel {
+	m := newViewModel(board.DefaultRuleset())
+	m.resize(120, 40)
+	return m
+}
+
+func TestNewViewModel(t *testing.T) {
+	m := newViewModel(board.DefaultRuleset())
+
+	if m.screen != "menu" || m.current != "menu" {
+		t.Errorf("newViewModel did not start on the menu, got %v screen and %v view", m.screen, m.current)
+	}
+	if len(m.hunter.Shots) == 0 {
+		t.Errorf("newViewModel did not recommend any shots")
+	}
+	if m.hunter.Rules.Name != board.DefaultRuleset().Name {
+		t.Errorf("newViewModel did not play the given rules, got %v", m.hunter.Rules.Name)
+	}
+}
+
+var minSizeTests = []struct {
+	width, height int
+	minX, minY    int
+}{
+	{10, 10, 71, 34},
+	{8, 8, 61, 34},
+	{20, 12, 121, 40},
+}
+
+func TestMinSize(t *testing.T) {
+	for _, test := range minSizeTests {
+		m := newViewModel(board.DefaultRuleset().WithSize(test.width, test.height))
+		if m.minX() != test.minX || m.minY() != test.minY {
+			t.Errorf("minX and minY did not return %vx%v for a %vx%v board, got %vx%v",
+				test.minX, test.minY, test.width, test.height, m.minX(), m.minY())
+		}
+	}
+}
+
+func TestFits(t *testing.T) {
+	m := testModel()
+	if !m.fits() {
+		t.Errorf("fits did not fit the grid in a 120x40 terminal")
+	}
+
+	m.resize(70, 40)
+	if m.fits() {
+		t.Errorf("fits did fit the grid in a 70x40 terminal")
+	}
+}
+
+var clickTests = []struct {
+	name     string
+	expected string
+}{
+	{"B4", "B4"},
+	{"select", "select"},
+	{"stats", "A1"},
+	{"grid", "A1"},
+	{"error", "A1"},
+}
+
+func TestClick(t *testing.T) {
+	for _, test := range clickTests {
+		m := testModel()
+		m.current = "A1"
+		m.click(test.name)
+		if m.current != test.expected {
+			t.Errorf("click on %v did not select %v, got %v", test.name, test.expected, m.current)
+		}
+	}
+
+	m := testModel()
+	m.current = "A1"
+	m.openPrompt("Test", []string{"A", "B"})
+	m.click("B4")
+	if m.current != "prompt" {
+		t.Errorf("click did not leave the prompt open, got %v", m.current)
+	}
+}
+
+func TestEnter(t *testing.T) {
+	m := testModel()
+	m.menuSelection = 0
+	m.enter()
+	if m.screen != "grid" || m.current != "select" {
+		t.Errorf("enter on Go Hunting did not switch to the grid, got %v screen and %v view", m.screen, m.current)
+	}
+
+	m.enter()
+	if m.current != "prompt" || m.selectedSquare != m.hunter.Shots[0] {
+		t.Errorf("enter on the top shot did not ask for its result, got %v view for %v", m.current, m.selectedSquare)
+	}
+}
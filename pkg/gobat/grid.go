@@ -2,113 +2,281 @@ package gobat
 
 import (
 	"fmt"
-	"strconv"
+	"strings"
 
 	"github.com/eaglerock1337/gobat/pkg/board"
 	"github.com/jroimartin/gocui"
 )
 
 var (
-	gridSelection = 0
-	gridControls  = []string{
+	gridControls = []string{
 		// "H - Help",
+		"U - Undo",
+		"^R - Redo",
 		"M - Menu",
 		"Q - Quit",
 	}
 	gridPrompt = []string{
 		"M - Miss",
 		"H - Hit",
+		"S - Sunk",
+		cancelPrompt,
 	}
 )
 
-var selectedSquare board.Square
+// unknownShip is the sunk prompt option for a ship whose type is not
+// announced, leaving the hunter to work out which ship it was
+const unknownShip = "Unknown Ship"
 
-// gridLayout provides the gocui manager function for the grid screen
-func gridLayout(g *gocui.Gui) error {
-	if err := showGridView(g); err != nil {
-		return err
-	}
-	if err := showSquareViews(g); err != nil {
-		return err
+// gridEnter handles enter key selection on any grid square, asking for the
+// result of the selected shot or square
+func (m *viewModel) gridEnter() {
+	switch m.current {
+	case "select", "stats":
+		if m.salvoMode() {
+			m.salvoEnter()
+			return
+		}
+		options := m.selectOptions()
+		switch {
+		case m.gridSelection < len(m.hunter.Shots):
+			m.openResultPrompt(m.hunter.Shots[m.gridSelection])
+		case m.gridSelection < len(options):
+			m.gridControl(options[m.gridSelection])
+		}
+	case "error", "grid":
+	default:
+		square, err := m.hunter.Board.SquareByString(m.current)
+		if err != nil || m.salvoMode() {
+			m.switchToGrid()
+			return
+		}
+		m.openResultPrompt(square)
 	}
-	if err := showSideViews(g); err != nil {
-		return err
+}
+
+// gridControl carries out one of the grid controls chosen from the select view
+func (m *viewModel) gridControl(control string) {
+	switch control {
+	case "U - Undo":
+		m.undoTurn()
+	case "^R - Redo":
+		m.redoTurn()
+	case "M - Menu":
+		m.switchToMenu()
+	case "Q - Quit":
+		m.quit = true
 	}
-	if err := showErrorView(g); err != nil {
-		return err
+}
+
+// openResultPrompt asks for the result of a shot at the given square, unless
+// the square has already been played
+func (m *viewModel) openResultPrompt(square board.Square) {
+	if !m.hunter.Board.IsEmpty(square) {
+		m.gridMessage = fmt.Sprintf("%s has already been played", square.PrintSquare())
+		return
 	}
-	return nil
+	m.selectedSquare = square
+	m.openPrompt(fmt.Sprintf("Result at %s", square.PrintSquare()), gridPrompt)
 }
 
-// gridEnterKeySelection handles enter key selection on any grid square
-func gridEnterKeySelection(g *gocui.Gui, v *gocui.View) error {
-	switch currentView {
-	case "select", "stats":
-		if gridSelection < len(theHunter.Shots) {
-			if err := theHunter.Turn(theHunter.Shots[gridSelection], "Miss"); err != nil {
-				return err
+// afloatTypes returns each type of ship still afloat once
+func (m *viewModel) afloatTypes() []string {
+	var types []string
+Ship:
+	for _, ship := range m.hunter.Ships {
+		for _, shipType := range types {
+			if shipType == ship.GetType() {
+				continue Ship
 			}
 		}
+		types = append(types, ship.GetType())
 	}
-	switchToGrid(g, v)
-	return nil
+	return types
 }
 
-// gridPromptEnterKeySelection handles enter key selection from the square selection prompt
-func gridPromptEnterKeySelection(g *gocui.Gui, v *gocui.View) error {
-	switch promptSelection {
-	case 0:
-		theHunter.Turn(selectedSquare, "Miss")
-	case 1:
-		theHunter.Turn(selectedSquare, "Hit")
+// sunkPrompt returns the options for the ship sunk by the selected square,
+// which are the types of ship still afloat
+func (m *viewModel) sunkPrompt() []string {
+	var options []string
+	if !m.hunter.Rules.AnnounceShip {
+		options = append(options, unknownShip)
 	}
-	return nil
+	options = append(options, m.afloatTypes()...)
+	return append(options, cancelPrompt)
 }
 
-// gridMouseClickSelection handles mouse click selection of a specific grid square
-func gridMouseClickSelection(g *gocui.Gui, v *gocui.View) {
-	n := v.Name()
-	g.SetCurrentView(n)
+// gridPromptEnter handles enter key selection from the square result prompt,
+// and from the prompt for which ship was sunk
+func (m *viewModel) gridPromptEnter() {
+	option, ok := m.promptOption()
+	if !ok {
+		return
+	}
+
+	switch option {
+	case cancelPrompt:
+		m.closePrompt()
+	case "M - Miss":
+		m.playTurn("Miss")
+	case "H - Hit":
+		m.playTurn("Hit")
+	case "S - Sunk":
+		m.openPrompt(fmt.Sprintf("Sunk at %s", m.selectedSquare.PrintSquare()), m.sunkPrompt())
+	case unknownShip:
+		m.playTurn("Sunk")
+	default:
+		m.playTurn(option)
+	}
 }
 
-// showGridView shows the grid view in the grid screen
-func showGridView(g *gocui.Gui) error {
-	vertLine := "    |    |    |    |    |    |    |    |    |"
-	horLine := "-------------------------------------------------"
+// playTurn closes the prompt and plays the selected square with the given
+// result, reporting any error on the grid screen
+func (m *viewModel) playTurn(result string) {
+	m.closePrompt()
+	if err := m.hunter.Turn(m.selectedSquare, result); err != nil {
+		m.gridMessage = err.Error()
+		return
+	}
+	m.turnPlayed()
+}
+
+// turnPlayed clears the grid screen of anything left over from the last turn
+func (m *viewModel) turnPlayed() {
+	m.gridMessage = ""
+	m.gridSelection = 0
+	m.resetSalvo()
+}
+
+// undoTurn handles the undo keybind, taking back the previous turn
+func (m *viewModel) undoTurn() {
+	switch m.current {
+	case "menu", "menubg", "prompt":
+		return
+	}
+	// an error only means there are no turns to undo, so there is nothing to do
+	if err := m.hunter.Undo(); err == nil {
+		m.gridSelection = 0
+		m.resetSalvo()
+	}
+}
+
+// redoTurn handles the redo keybind, playing the last undone turn again
+func (m *viewModel) redoTurn() {
+	switch m.current {
+	case "menu", "menubg", "prompt":
+		return
+	}
+	// an error only means there are no turns to redo, so there is nothing to do
+	if err := m.hunter.Redo(); err == nil {
+		m.gridSelection = 0
+		m.resetSalvo()
+	}
+}
+
+// selectOptions returns every line that can be selected in the select view
+func (m *viewModel) selectOptions() []string {
+	var options []string
+	if m.salvoMode() {
+		options = m.salvoSelectOptions()
+	} else {
+		for i, square := range m.hunter.Shots {
+			options = append(options, fmt.Sprintf("%d - %s (%d)", i+1, square.PrintSquare(), m.hunter.HeatMap.GetSquare(square)))
+		}
+	}
+	return append(options, gridControls...)
+}
+
+// switchToGrid switches to the grid screen, selecting the top shot, as long
+// as the terminal is large enough to show it
+func (m *viewModel) switchToGrid() {
+	if !m.fits() {
+		return
+	}
+	m.closePrompt()
+	m.screen = "grid"
+	m.current = "select"
+	m.gridSelection = 0
+	m.resetSalvo()
+}
+
+// gridLayout provides the gocui manager function for the grid screen
+func (a *App) gridLayout(g *gocui.Gui) error {
+	a.resize(g.Size())
+	if err := a.showGridView(g); err != nil {
+		return err
+	}
+	if err := a.showSquareViews(g); err != nil {
+		return err
+	}
+	if err := a.showPromptView(g); err != nil {
+		return err
+	}
+	if err := a.showCommandView(g); err != nil {
+		return err
+	}
+	if err := a.showSideViews(g); err != nil {
+		return err
+	}
+	if err := a.showErrorView(g); err != nil {
+		return err
+	}
+	return nil
+}
 
-	if v, err := g.SetView("grid", 0, 0, gridX, minY-1); err != nil {
+// showGridView shows the grid view in the grid screen
+func (a *App) showGridView(g *gocui.Gui) error {
+	if v, err := g.SetView("grid", 0, 0, a.gridX(), a.gridY()); err != nil {
 		if err != gocui.ErrUnknownView {
 			return err
 		}
 		v.Title = "Battleship Grid"
-		for i := 1; i <= 30; i++ {
-			line := vertLine
-			if i%3 == 0 {
-				line = horLine
-			}
-			fmt.Fprintln(v, line)
-		}
+	} else {
+		a.refreshGridView(v)
 	}
 
 	return nil
 }
 
-// showSquareViews shows all square views in the grid screen
-func showSquareViews(g *gocui.Gui) error {
-	letters := "ABCDEFGHIJ"
-
-	for row, letter := range letters {
-		for col := 0; col < 10; col++ {
-			viewName := string(letter) + strconv.Itoa(col+1)
-			if v, err := g.SetView(viewName, row*5, col*3, row*5+5, col*3+3); err != nil {
-				if err != gocui.ErrUnknownView {
-					return err
-				}
-				v.Frame = false
-				v.SelBgColor = gocui.ColorWhite
-				v.SelFgColor = gocui.ColorBlack
-			} else {
-				refreshSquareView(v)
+// refreshGridView redraws the lines between the squares of the grid, which
+// change whenever a game on a board of another size is loaded
+func (a *App) refreshGridView(v *gocui.View) {
+	vertLine := strings.Repeat(strings.Repeat(" ", squareX-1)+"|", a.hunter.Board.Width()-1)
+	horLine := strings.Repeat("-", a.gridX()-1)
+
+	v.Clear()
+	for i := 1; i <= a.gridY(); i++ {
+		line := vertLine
+		if i%squareY == 0 {
+			line = horLine
+		}
+		fmt.Fprintln(v, line)
+	}
+}
+
+// showSquareViews shows all square views in the grid screen, removing the
+// views of any squares left over from a larger board
+func (a *App) showSquareViews(g *gocui.Gui) error {
+	max := a.maxHeat()
+	for _, square := range a.hunter.Board.Squares() {
+		row, col := square.Letter, square.Number
+		viewName := square.PrintSquare()
+		if v, err := g.SetView(viewName, row*squareX, col*squareY, (row+1)*squareX, (col+1)*squareY); err != nil {
+			if err != gocui.ErrUnknownView {
+				return err
+			}
+			v.Frame = false
+			v.SelBgColor = gocui.ColorWhite
+			v.SelFgColor = gocui.ColorBlack
+		} else {
+			a.refreshSquareView(v, max)
+		}
+	}
+
+	for _, v := range g.Views() {
+		if square, err := board.SquareByString(v.Name()); err == nil && !a.hunter.Board.Contains(square) {
+			if err := g.DeleteView(v.Name()); err != nil {
+				return err
 			}
 		}
 	}
@@ -116,97 +284,119 @@ func showSquareViews(g *gocui.Gui) error {
 	return nil
 }
 
-// refreshSquareView refreshes a specific square on the grid screen
-func refreshSquareView(v *gocui.View) {
+// refreshSquareView refreshes a specific square on the grid screen, colored
+// by its heat relative to the hottest open square
+func (a *App) refreshSquareView(v *gocui.View, max int) {
 	v.Clear()
 
-	fmt.Fprintf(v, " %s \n", v.Name())
+	// squares past column Z need every column of the view for their name
+	if len(v.Name()) < squareX-1 {
+		fmt.Fprintf(v, " %s \n", v.Name())
+	} else {
+		fmt.Fprintf(v, "%s\n", v.Name())
+	}
 	square, _ := board.SquareByString(v.Name())
-	fmt.Fprintf(v, " %d", theHunter.HeatMap.GetSquare(square))
+	style := a.squareStyle(square, max)
+	fmt.Fprintf(v, " %s", style.text)
 	v.SetCursor(0, 0)
 
-	if theHunter.InShots(square) {
-		v.BgColor = gocui.ColorGreen
-	} else {
-		v.BgColor = gocui.ColorDefault
+	v.BgColor = gocui.ColorDefault
+	if style.color >= 0 {
+		// the 256-color palette is offset by one for the default color
+		v.BgColor = gocui.Attribute(style.color + 1)
 	}
-
-	v.Highlight = false
-	if v.Name() == currentView {
-		v.Highlight = true
+	v.FgColor = gocui.ColorDefault
+	if style.color >= 0 {
+		v.FgColor = gocui.ColorBlack
 	}
+	if style.light {
+		v.FgColor = gocui.ColorWhite
+	}
+	if style.shot {
+		v.FgColor |= gocui.AttrBold | gocui.AttrUnderline
+	}
+
+	v.Highlight = v.Name() == a.current
 }
 
 // showSideViews shows all side views in the grid screen
-func showSideViews(g *gocui.Gui) error {
+func (a *App) showSideViews(g *gocui.Gui) error {
 	maxX, _ := g.Size()
-	if maxX < minX {
+	if maxX < a.minX() {
 		return nil
 	}
-	if err := showStatsView(g); err != nil {
+	if err := a.showStatsView(g); err != nil {
 		return err
 	}
-	if err := showSelectView(g); err != nil {
+	if err := a.showSelectView(g); err != nil {
 		return err
 	}
-	if _, err := g.SetCurrentView(currentView); err != nil {
+	if _, err := g.SetCurrentView(a.current); err != nil {
 		return err
 	}
 	return nil
 }
 
 // showStatsView shows the stats view in the grid screen
-func showStatsView(g *gocui.Gui) error {
+func (a *App) showStatsView(g *gocui.Gui) error {
 	maxX, _ := g.Size()
 
-	if v, err := g.SetView("stats", gridX+1, 0, maxX-1, 2*minY/3); err != nil {
+	if v, err := g.SetView("stats", a.gridX()+1, 0, maxX-1, 2*a.commandTop()/3); err != nil {
 		if err != gocui.ErrUnknownView {
 			return err
 		}
 		v.Title = "Game Statistics"
 		v.Wrap = true
 	} else {
-		refreshStatsView(v)
+		a.refreshStatsView(v)
 	}
 
 	return nil
 }
 
 // refreshStatsView refreshes the status view on the grid screen
-func refreshStatsView(v *gocui.View) {
+func (a *App) refreshStatsView(v *gocui.View) {
 	v.Clear()
 
 	perms := 0
 	fmt.Fprintf(v, "Remaining ships:\n")
 
-	for _, ship := range theHunter.Ships {
+	for i, ship := range a.hunter.Ships {
 		fmt.Fprintf(v, "  %s\n", ship.GetType())
-		perms += theHunter.Data[ship.GetLength()].Len()
+		perms += a.hunter.Data[i].Len()
 	}
 
-	fmt.Fprintf(v, "\nTurns Taken: %d\n", theHunter.Turns)
+	fmt.Fprintf(v, "\nTurns Taken: %d\n", a.hunter.Turns)
 	fmt.Fprintf(v, "Total Perms: %d\n", perms)
 
 	mode := "Destroy"
-	if theHunter.SeekMode {
+	if a.hunter.SeekMode {
 		mode = "Seek"
 	}
 	fmt.Fprintf(v, "Hunter: %s\n", mode)
 
+	if c := a.hunter.Contradiction; c != nil {
+		fmt.Fprintf(v, "\nImpossible since turn %d (%s %s)\n", c.Turn, c.Move.Square.PrintSquare(), c.Move.Result)
+	}
+
 	fmt.Fprintf(v, "\nActive Hitstack:\n")
-	for _, square := range theHunter.HitStack {
+	for _, square := range a.hunter.HitStack {
 		fmt.Fprintf(v, "%s ", square.PrintSquare())
 	}
-	if len(theHunter.HitStack) == 0 {
+	if len(a.hunter.HitStack) == 0 {
 		fmt.Fprint(v, "  Empty")
 	}
+
+	if a.gridMessage != "" {
+		fmt.Fprintf(v, "\n\n%s", a.gridMessage)
+	}
 }
 
 // showSelectView shows the select view in the grid screen
-func showSelectView(g *gocui.Gui) error {
+func (a *App) showSelectView(g *gocui.Gui) error {
 	maxX, _ := g.Size()
 
-	if v, err := g.SetView("select", gridX+1, 2*minY/3+1, maxX-1, minY-1); err != nil {
+	if v, err := g.SetView("select", a.gridX()+1, 2*a.commandTop()/3+1, maxX-1, a.commandTop()-1); err != nil {
 		if err != gocui.ErrUnknownView {
 			return err
 		}
@@ -216,32 +406,26 @@ func showSelectView(g *gocui.Gui) error {
 		v.SelBgColor = gocui.ColorWhite
 		v.SelFgColor = gocui.ColorBlack
 	} else {
-		refreshSelectView(v)
+		a.refreshSelectView(v)
 	}
 
 	return nil
 }
 
 // refreshSelectView refreshes the select view in the grid screen
-func refreshSelectView(v *gocui.View) {
+func (a *App) refreshSelectView(v *gocui.View) {
 	v.Clear()
 
-	for i, square := range theHunter.Shots {
-		fmt.Fprintf(v, "%d - %s (%d)\n", i+1, square.PrintSquare(), theHunter.HeatMap.GetSquare(square))
-	}
-	for _, line := range gridControls {
+	for _, line := range a.selectOptions() {
 		fmt.Fprintln(v, line)
 	}
-	v.SetCursor(0, gridSelection)
+	v.SetCursor(0, a.gridSelection)
 
-	v.Highlight = false
-	if currentView == "select" {
-		v.Highlight = true
-	}
+	v.Highlight = a.current == "select"
 }
 
 // showErrorView shows the error view
-func showErrorView(g *gocui.Gui) error {
+func (a *App) showErrorView(g *gocui.Gui) error {
 	maxX, maxY := g.Size()
 
 	if v, err := g.SetView("error", maxX/3, maxY/3, 2*maxX/3, 2*maxY/3); err != nil {
@@ -250,7 +434,7 @@ func showErrorView(g *gocui.Gui) error {
 		}
 		v.Title = "Screen too small"
 	} else {
-		if err := refreshErrorView(g, v); err != nil {
+		if err := a.refreshErrorView(g, v); err != nil {
 			return err
 		}
 	}
@@ -258,14 +442,14 @@ func showErrorView(g *gocui.Gui) error {
 }
 
 // refreshErrorView refreshes the error view in the grid screen
-func refreshErrorView(g *gocui.Gui, v *gocui.View) error {
+func (a *App) refreshErrorView(g *gocui.Gui, v *gocui.View) error {
 	maxX, maxY := g.Size()
 
-	if maxX < minX || maxY < minY {
+	if maxX < a.minX() || maxY < a.minY() {
 		v.BgColor = gocui.ColorRed
 		if v, err := g.SetViewOnTop("error"); err == nil {
 			v.Clear()
-			fmt.Fprintf(v, "Need: %dx%d\n", minX, minY)
+			fmt.Fprintf(v, "Need: %dx%d\n", a.minX(), a.minY())
 			fmt.Fprintf(v, "Have: %dx%d\n", maxX, maxY)
 			for _, line := range gridControls {
 				fmt.Fprintln(v, line)
@@ -281,19 +465,3 @@ func refreshErrorView(g *gocui.Gui, v *gocui.View) error {
 
 	return nil
 }
-
-// switchToGrid switches to grid view
-func switchToGrid(g *gocui.Gui, v *gocui.View) error {
-	currentView = "select"
-	maxX, maxY := g.Size()
-
-	if minX <= maxX && minY <= maxY {
-		gridSelection = 0
-		g.SetCurrentView(currentView)
-
-		g.SetManagerFunc(gridLayout)
-		setKeyBindings(g)
-	}
-
-	return nil
-}
@@ -0,0 +1,164 @@
+package gobat
+
+import (
+	"testing"
+
+	"github.com/eaglerock1337/gobat/pkg/board"
+)
+
+// gridModel returns a view-model on the grid screen for the given rules
+func gridModel(rules board.Ruleset) *viewModel {
+	m := newViewModel(rules)
+	m.resize(120, 40)
+	m.switchToGrid()
+	return m
+}
+
+// playSquare answers the result prompt for the given square with the given options
+func playSquare(m *viewModel, square string, options ...string) {
+	m.current = square
+	m.enter()
+	for _, option := range options {
+		selectPrompt(m, option)
+	}
+}
+
+func TestSwitchToGrid(t *testing.T) {
+	m := testModel()
+	m.resize(60, 20)
+	m.switchToGrid()
+	if m.screen != "menu" {
+		t.Errorf("switchToGrid did switch to a grid that does not fit")
+	}
+
+	m.resize(120, 40)
+	m.gridSelection = 3
+	m.switchToGrid()
+	if m.screen != "grid" || m.current != "select" || m.gridSelection != 0 {
+		t.Errorf("switchToGrid did not select the top shot, got %v screen and %v view at %v",
+			m.screen, m.current, m.gridSelection)
+	}
+}
+
+func TestPlayTurn(t *testing.T) {
+	m := gridModel(board.DefaultRuleset())
+	playSquare(m, "C4", "M - Miss")
+
+	square, _ := board.SquareByString("C4")
+	if m.hunter.Turns != 1 || !m.hunter.Board.IsMiss(square) {
+		t.Errorf("playTurn did not play a miss at C4, got %v turns", m.hunter.Turns)
+	}
+	if m.current != "C4" {
+		t.Errorf("playTurn did not return to the square, got %v", m.current)
+	}
+
+	playSquare(m, "C4")
+	if m.current != "C4" || m.gridMessage == "" {
+		t.Errorf("openResultPrompt did not refuse a square already played, got %v", m.current)
+	}
+
+	playSquare(m, "C5", "H - Hit")
+	if m.gridMessage != "" || m.hunter.Turns != 2 {
+		t.Errorf("playTurn did not clear the message after a turn, got %v", m.gridMessage)
+	}
+}
+
+func TestPlayTurnFailed(t *testing.T) {
+	m := gridModel(board.DefaultRuleset())
+	playSquare(m, "E5", "S - Sunk", "Carrier")
+
+	if m.hunter.Turns != 0 || m.gridMessage == "" {
+		t.Errorf("playTurn did not report a sink that cannot be played, got %v turns", m.hunter.Turns)
+	}
+}
+
+var sunkPromptTests = []struct {
+	rules    string
+	expected []string
+}{
+	{"Milton Bradley 1967", []string{"Carrier", "Battleship", "Cruiser", "Submarine", "Destroyer", cancelPrompt}},
+	{"Russian", []string{unknownShip, "Battleship", "Cruiser", "Destroyer", "Submarine", cancelPrompt}},
+}
+
+func TestSunkPrompt(t *testing.T) {
+	for _, test := range sunkPromptTests {
+		rules, _ := board.RulesetByName(test.rules)
+		m := gridModel(rules)
+		options := m.sunkPrompt()
+		if len(options) != len(test.expected) {
+			t.Errorf("sunkPrompt did not return %v for %v, got %v", test.expected, test.rules, options)
+			continue
+		}
+		for i := range options {
+			if options[i] != test.expected[i] {
+				t.Errorf("sunkPrompt did not return %v for %v, got %v", test.expected, test.rules, options)
+				break
+			}
+		}
+	}
+}
+
+func TestSelectOptions(t *testing.T) {
+	m := gridModel(board.DefaultRuleset())
+	options := m.selectOptions()
+
+	if len(options) != len(m.hunter.Shots)+len(gridControls) {
+		t.Errorf("selectOptions did not list every shot and control, got %v", options)
+	}
+	if options[len(options)-1] != "Q - Quit" {
+		t.Errorf("selectOptions did not end with the controls, got %v", options)
+	}
+}
+
+func TestGridControl(t *testing.T) {
+	m := gridModel(board.DefaultRuleset())
+	playSquare(m, "D4", "M - Miss")
+
+	m.current = "select"
+	m.gridSelection = len(m.hunter.Shots)
+	m.enter()
+	if m.hunter.Turns != 0 {
+		t.Errorf("gridControl did not undo the turn, got %v turns", m.hunter.Turns)
+	}
+
+	m.gridSelection = len(m.hunter.Shots) + 1
+	m.enter()
+	if m.hunter.Turns != 1 {
+		t.Errorf("gridControl did not redo the turn, got %v turns", m.hunter.Turns)
+	}
+
+	m.gridSelection = len(m.hunter.Shots) + 2
+	m.enter()
+	if m.screen != "menu" || m.current != "menu" {
+		t.Errorf("gridControl did not switch to the menu, got %v screen and %v view", m.screen, m.current)
+	}
+
+	m.switchToGrid()
+	m.gridSelection = len(m.hunter.Shots) + 3
+	m.enter()
+	if !m.quit {
+		t.Errorf("gridControl did not quit")
+	}
+}
+
+func TestUndoTurn(t *testing.T) {
+	m := gridModel(board.DefaultRuleset())
+	playSquare(m, "F6", "M - Miss")
+
+	m.current = "prompt"
+	m.undoTurn()
+	if m.hunter.Turns != 1 {
+		t.Errorf("undoTurn did undo a turn from the prompt")
+	}
+
+	m.current = "F6"
+	m.undoTurn()
+	if m.hunter.Turns != 0 {
+		t.Errorf("undoTurn did not undo the turn, got %v turns", m.hunter.Turns)
+	}
+
+	m.redoTurn()
+	if m.hunter.Turns != 1 {
+		t.Errorf("redoTurn did not redo the turn, got %v turns", m.hunter.Turns)
+	}
+}
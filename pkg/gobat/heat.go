@@ -0,0 +1,78 @@
+package gobat
+
+import (
+	"fmt"
+
+	"github.com/eaglerock1337/gobat/pkg/board"
+)
+
+// heatColors is the gradient of 256-color palette backgrounds for the heat of
+// an open square, running from the coldest blue through green and yellow to
+// the hottest red
+var heatColors = []int{
+	17, 18, 19, 20, 21, 27, 33, 39, 45, 51, 50, 49, 48, 47, 46,
+	82, 118, 154, 190, 226, 220, 214, 208, 202, 196,
+}
+
+// darkHeatColors is how many of the coldest heat colors need light text
+const darkHeatColors = 8
+
+// squareStyle describes how a square of the grid is drawn
+type squareStyle struct {
+	text  string // The heat of an open square, or the glyph of a played square
+	color int    // The 256-color palette background, or -1 for the default
+	light bool   // Whether the background is dark enough to need light text
+	shot  bool   // Whether the square is one of the recommended shots
+}
+
+// playedStyles are the glyphs and colors of every played square, with each
+// sunk ship marked by its hull classification
+var playedStyles = map[string]squareStyle{
+	"Miss":       {text: "M", color: 244},
+	"Hit":        {text: "H", color: 160, light: true},
+	"Destroyer":  {text: "DD", color: 139},
+	"Submarine":  {text: "SS", color: 60, light: true},
+	"Cruiser":    {text: "CA", color: 130, light: true},
+	"Battleship": {text: "BB", color: 96, light: true},
+	"Carrier":    {text: "CV", color: 54, light: true},
+}
+
+// maxHeat returns the heat of the hottest open square on the board
+func (m *viewModel) maxHeat() int {
+	max := 0
+	for _, square := range m.hunter.Board.Squares() {
+		if heat := m.hunter.HeatMap.GetSquare(square); m.hunter.Board.IsEmpty(square) && heat > max {
+			max = heat
+		}
+	}
+	return max
+}
+
+// heatLevel returns the step of the heat gradient for an open square
+// relative to the hottest open square, or -1 for a square no ship can be on
+func heatLevel(heat, max int) int {
+	if heat <= 0 || max <= 0 {
+		return -1
+	}
+	if heat >= max {
+		return len(heatColors) - 1
+	}
+	return heat * len(heatColors) / max
+}
+
+// squareStyle returns how the given square is drawn, where open squares are
+// colored by their heat relative to the hottest open square
+func (m *viewModel) squareStyle(square board.Square, max int) squareStyle {
+	if !m.hunter.Board.IsEmpty(square) {
+		return playedStyles[m.hunter.Board.GetString(square)]
+	}
+
+	heat := m.hunter.HeatMap.GetSquare(square)
+	style := squareStyle{text: fmt.Sprint(heat), color: -1}
+	if level := heatLevel(heat, max); level >= 0 {
+		style.color = heatColors[level]
+		style.light = level < darkHeatColors
+	}
+	style.shot = (!m.salvoMode() && m.hunter.InShots(square)) || m.inSalvo(square)
+	return style
+}
@@ -0,0 +1,85 @@
+package gobat
+
+import (
+	"testing"
+
+	"github.com/eaglerock1337/gobat/pkg/board"
+)
+
+var heatLevelTests = []struct {
+	heat, max int
+	expected  int
+}{
+	{0, 100, -1},
+	{5, 0, -1},
+	{1, 100, 0},
+	{50, 100, 12},
+	{99, 100, 24},
+	{100, 100, 24},
+	{150, 100, 24},
+}
+
+func TestHeatLevel(t *testing.T) {
+	for _, test := range heatLevelTests {
+		if level := heatLevel(test.heat, test.max); level != test.expected {
+			t.Errorf("heatLevel did not return %v for %v of %v, got %v", test.expected, test.heat, test.max, level)
+		}
+	}
+}
+
+func TestPlayedStyles(t *testing.T) {
+	glyphs := map[string]bool{}
+	colors := map[int]bool{}
+	for _, color := range heatColors {
+		colors[color] = true
+	}
+
+	for _, result := range []string{"Miss", "Hit", "Destroyer", "Submarine", "Cruiser", "Battleship", "Carrier"} {
+		style, ok := playedStyles[result]
+		if !ok {
+			t.Errorf("playedStyles did not have a style for %v", result)
+			continue
+		}
+		if glyphs[style.text] || colors[style.color] {
+			t.Errorf("playedStyles did not give %v a distinct glyph and color, got %v", result, style)
+		}
+		glyphs[style.text] = true
+		colors[style.color] = true
+	}
+}
+
+func TestSquareStyle(t *testing.T) {
+	m := gridModel(board.DefaultRuleset())
+	playSquare(m, "A1", "H - Hit")
+	playSquare(m, "A2", "S - Sunk", "Destroyer")
+	playSquare(m, "J10", "M - Miss")
+	playSquare(m, "E5", "H - Hit")
+	max := m.maxHeat()
+
+	expected := map[string]string{"A1": "DD", "A2": "DD", "J10": "M", "E5": "H"}
+	for name, text := range expected {
+		square, _ := board.SquareByString(name)
+		if style := m.squareStyle(square, max); style.text != text {
+			t.Errorf("squareStyle did not mark %v with %v, got %v", name, text, style.text)
+		}
+	}
+
+	hottest := m.hunter.Shots[0]
+	style := m.squareStyle(hottest, max)
+	if style.color != heatColors[len(heatColors)-1] || !style.shot {
+		t.Errorf("squareStyle did not color the top shot %v as the hottest shot, got %v", hottest.PrintSquare(), style)
+	}
+
+	square, _ := board.SquareByString("J1")
+	style = m.squareStyle(square, max)
+	if style.text != "0" || style.color != -1 || style.shot {
+		t.Errorf("squareStyle did not leave the cold square J1 uncolored, got %v", style)
+	}
+}
+
+func TestMaxHeat(t *testing.T) {
+	m := gridModel(board.DefaultRuleset())
+	if max := m.maxHeat(); max != m.hunter.HeatMap.GetSquare(m.hunter.Shots[0]) {
+		t.Errorf("maxHeat did not return the heat of the top shot, got %v", max)
+	}
+}
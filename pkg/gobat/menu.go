@@ -2,51 +2,179 @@ package gobat
 
 import (
 	"fmt"
+	"os"
+	"path/filepath"
 
+	"github.com/eaglerock1337/gobat/pkg/board"
+	"github.com/eaglerock1337/gobat/pkg/hunter"
 	"github.com/jroimartin/gocui"
 )
 
-var menuSelection = 0
+const (
+	newGamePrompt  = "New Game"
+	confirmPrompt  = "Abandon this game?"
+	confirmNewGame = "Y - New Game"
+	confirmReset   = "Y - Reset Hunter"
+)
+
 var menuControls = []string{
 	"G - Go Hunting",
-	// "R - Reset Hunter",
+	"N - New Game",
+	"R - Reset Hunter",
+	"S - Save Game",
+	"L - Load Game",
+	"V - Salvo Mode",
 	"Q - Quit Gobat",
 }
 
+// menuEnter handles menu enter key selection
+func (m *viewModel) menuEnter() {
+	switch menuControls[m.menuSelection] {
+	case "G - Go Hunting":
+		m.switchToGrid()
+	case "N - New Game":
+		m.openPrompt(newGamePrompt, m.rulesetOptions())
+	case "R - Reset Hunter":
+		m.openPrompt(confirmPrompt, []string{confirmReset, cancelPrompt})
+	case "S - Save Game":
+		m.saveGame()
+	case "L - Load Game":
+		m.loadGame()
+	case "V - Salvo Mode":
+		m.toggleSalvoMode()
+	case "Q - Quit Gobat":
+		m.quit = true
+	}
+}
+
+// newGame replaces the hunter with a fresh one for the given rules, keeping salvo mode
+func (m *viewModel) newGame(rules board.Ruleset) {
+	hunt := hunter.NewHunterWithRules(rules)
+	hunt.Options.Salvo = m.hunter.Options.Salvo
+	hunt.Seek()
+	m.replaceHunter(hunt)
+}
+
+// replaceHunter replaces the game being played with the given hunter,
+// clearing what the grid screen kept from the last game
+func (m *viewModel) replaceHunter(hunt hunter.Hunter) {
+	m.hunter = hunt
+	m.gridSelection = 0
+	m.gridMessage = ""
+	m.resetSalvo()
+}
+
+// rulesetOptions returns the names of the rulesets a new game can be played
+// with, starting with the current rules, and stores the rulesets in menuRules
+func (m *viewModel) rulesetOptions() []string {
+	m.menuRules = []board.Ruleset{m.hunter.Rules}
+	for _, rules := range board.Rulesets() {
+		if rules.Name != m.hunter.Rules.Name {
+			m.menuRules = append(m.menuRules, rules)
+		}
+	}
+
+	var options []string
+	for _, rules := range m.menuRules {
+		options = append(options, rules.Name)
+	}
+	return append(options, cancelPrompt)
+}
+
+// menuPromptEnter processes the new game and reset prompts of the menu
+func (m *viewModel) menuPromptEnter() {
+	option, ok := m.promptOption()
+	if !ok {
+		return
+	}
+
+	switch {
+	case option == cancelPrompt:
+		m.closePrompt()
+	case option == confirmNewGame:
+		m.newGame(m.menuRules[0])
+		m.menuMessage = fmt.Sprintf("Started a new game of %s", m.hunter.Rules.Name)
+		m.closePrompt()
+	case option == confirmReset:
+		m.newGame(m.hunter.Rules)
+		m.menuMessage = fmt.Sprintf("Reset the hunter for %s", m.hunter.Rules.Name)
+		m.closePrompt()
+	case m.promptName == newGamePrompt:
+		// keep only the chosen rules until the new game is confirmed
+		m.menuRules = m.menuRules[m.promptSelection : m.promptSelection+1]
+		m.openPrompt(confirmPrompt, []string{confirmNewGame, cancelPrompt})
+	}
+}
+
+// savePath returns the location of the saved game file
+func savePath() (string, error) {
+	home, err := os.UserHomeDir()
+	if err != nil {
+		return "", err
+	}
+	return filepath.Join(home, ".gobat", "save.json"), nil
+}
+
+// saveGame saves the current game and reports the result on the menu
+func (m *viewModel) saveGame() {
+	path, err := savePath()
+	if err == nil {
+		err = m.hunter.SaveFile(path)
+	}
+	if err != nil {
+		m.menuMessage = fmt.Sprintf("Save failed: %v", err)
+		return
+	}
+	m.menuMessage = fmt.Sprintf("Saved turn %d to %s", m.hunter.Turns, path)
+}
+
+// loadGame replaces the current game with the saved game and reports the
+// result on the menu
+func (m *viewModel) loadGame() {
+	path, err := savePath()
+	var hunt hunter.Hunter
+	if err == nil {
+		hunt, err = hunter.LoadFile(path)
+	}
+	if err != nil {
+		m.menuMessage = fmt.Sprintf("Load failed: %v", err)
+		return
+	}
+	m.replaceHunter(hunt)
+	m.menuMessage = fmt.Sprintf("Loaded turn %d from %s", m.hunter.Turns, path)
+}
+
+// switchToMenu switches to the menu screen
+func (m *viewModel) switchToMenu() {
+	if m.current == "menu" {
+		return
+	}
+	m.closePrompt()
+	m.screen = "menu"
+	m.current = "menu"
+	m.menuSelection = 0
+}
+
 // menuLayout provides the gocui manager function for the main menu
-func menuLayout(g *gocui.Gui) error {
+func (a *App) menuLayout(g *gocui.Gui) error {
+	a.resize(g.Size())
 	if err := showMenuBackgroundView(g); err != nil {
 		return err
 	}
-	if err := showMenuView(g); err != nil {
+	if err := a.showMenuView(g); err != nil {
 		return err
 	}
-	return nil
-}
-
-// menuEnterKeySelection handles menu enter key selection
-func menuEnterKeySelection(g *gocui.Gui, v *gocui.View) error {
-	switch menuSelection {
-	case 0:
-		if err := switchToGrid(g, v); err != nil {
-			return err
-		}
-	case 1:
-		g.Update(func(g *gocui.Gui) error {
-			return gocui.ErrQuit
-		})
+	if err := a.showPromptView(g); err != nil {
+		return err
+	}
+	if _, err := g.SetCurrentView(a.current); err != nil {
+		return err
 	}
 	return nil
 }
 
-// menuMouseClickSelection handles menu mouse click selection
-func menuMouseClickSelection(g *gocui.Gui, v *gocui.View) {
-	currentView = v.Name()
-	g.SetCurrentView(currentView)
-}
-
 // showMenuView shows the menu view in the menu screen
-func showMenuView(g *gocui.Gui) error {
+func (a *App) showMenuView(g *gocui.Gui) error {
 	maxX, maxY := g.Size()
 
 	if v, err := g.SetView("menu", maxX/3, maxY/3, 2*maxX/3, 2*maxY/3); err != nil {
@@ -54,29 +182,33 @@ func showMenuView(g *gocui.Gui) error {
 			return err
 		}
 		v.Title = "Gobat Hunter"
+		v.Wrap = true
 		v.Highlight = true
 		v.SelBgColor = gocui.ColorWhite
 		v.SelFgColor = gocui.ColorBlack
 	} else {
-		refreshMenuView(g, v)
+		a.refreshMenuView(v)
 	}
 
 	return nil
 }
 
 // refreshMenuView refreshes the menu view in the menu screen
-func refreshMenuView(g *gocui.Gui, v *gocui.View) {
-	maxX, maxY := g.Size()
+func (a *App) refreshMenuView(v *gocui.View) {
 	v.Clear()
 	for _, line := range menuControls {
 		fmt.Fprintln(v, line)
 	}
-	fmt.Fprintf(v, "\nMin Size: %dx%d", minX, minY)
-	fmt.Fprintf(v, "\nCur Size: %dx%d", maxX, maxY)
+	fmt.Fprintf(v, "\nMin Size: %dx%d", a.minX(), a.minY())
+	fmt.Fprintf(v, "\nCur Size: %dx%d", a.maxX, a.maxY)
+	if a.menuMessage != "" {
+		fmt.Fprintf(v, "\n\n%s", a.menuMessage)
+	}
 
-	v.SetCursor(0, menuSelection)
+	v.SetCursor(0, a.menuSelection)
+	v.Highlight = a.current != "prompt"
 	v.SelBgColor = gocui.ColorWhite
-	if menuSelection == 0 && (maxX < minX || maxY < minY) {
+	if a.menuSelection == 0 && !a.fits() {
 		v.SelBgColor = gocui.ColorRed
 	}
 }
@@ -91,16 +223,3 @@ func showMenuBackgroundView(g *gocui.Gui) error {
 	}
 	return nil
 }
-
-// switchToMenu switches to menu view
-func switchToMenu(g *gocui.Gui, v *gocui.View) error {
-	if currentView == "menu" {
-		return nil
-	}
-	currentView = "menu"
-	g.SetManagerFunc(menuLayout)
-	setKeyBindings(g)
-	menuSelection = 0
-	refreshMenuView(g, v)
-	return nil
-}
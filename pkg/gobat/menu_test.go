@@ -0,0 +1,109 @@
+package gobat
+
+import (
+	"testing"
+
+	"github.com/eaglerock1337/gobat/pkg/board"
+)
+
+// selectMenu selects the given menu control and presses enter
+func selectMenu(m *viewModel, control string) {
+	for i, c := range menuControls {
+		if c == control {
+			m.menuSelection = i
+		}
+	}
+	m.enter()
+}
+
+// selectPrompt selects the given prompt option and presses enter
+func selectPrompt(m *viewModel, option string) {
+	for i, o := range m.promptOptions {
+		if o == option {
+			m.promptSelection = i
+		}
+	}
+	m.enter()
+}
+
+func TestRulesetOptions(t *testing.T) {
+	rules, _ := board.RulesetByName("Russian")
+	m := newViewModel(rules.WithSize(8, 8))
+	options := m.rulesetOptions()
+
+	if len(options) != len(board.Rulesets())+1 {
+		t.Errorf("rulesetOptions did not offer every ruleset once, got %v", options)
+	}
+	if options[0] != "Russian" || m.menuRules[0].Width != 8 {
+		t.Errorf("rulesetOptions did not offer the current rules first, got %v", options)
+	}
+	if options[len(options)-1] != cancelPrompt {
+		t.Errorf("rulesetOptions did not end with the cancel option, got %v", options)
+	}
+}
+
+func TestNewGame(t *testing.T) {
+	m := gridModel(board.DefaultRuleset())
+	playSquare(m, "B2", "M - Miss")
+	m.switchToMenu()
+
+	selectMenu(m, "N - New Game")
+	selectPrompt(m, "Russian")
+	if m.current != "prompt" || m.promptName != confirmPrompt {
+		t.Errorf("the New Game prompt did not ask for confirmation, got %v", m.promptName)
+	}
+	if m.hunter.Turns != 1 {
+		t.Errorf("the New Game prompt did replace the game before confirmation")
+	}
+
+	selectPrompt(m, confirmNewGame)
+	if m.current != "menu" || m.hunter.Rules.Name != "Russian" || m.hunter.Turns != 0 {
+		t.Errorf("the New Game prompt did not start a new game of Russian, got %v after %v turns",
+			m.hunter.Rules.Name, m.hunter.Turns)
+	}
+}
+
+func TestResetHunter(t *testing.T) {
+	m := gridModel(board.DefaultRuleset())
+	playSquare(m, "B2", "M - Miss")
+	m.switchToMenu()
+
+	selectMenu(m, "R - Reset Hunter")
+	selectPrompt(m, cancelPrompt)
+	if m.current != "menu" || m.hunter.Turns != 1 {
+		t.Errorf("cancelling the reset did not keep the game, got %v turns", m.hunter.Turns)
+	}
+
+	selectMenu(m, "R - Reset Hunter")
+	selectPrompt(m, confirmReset)
+	if m.hunter.Turns != 0 || m.hunter.Rules.Name != board.DefaultRuleset().Name {
+		t.Errorf("the reset did not start the game over, got %v turns", m.hunter.Turns)
+	}
+	if m.menuMessage == "" {
+		t.Errorf("the reset did not report on the menu")
+	}
+}
+
+func TestSwitchToMenu(t *testing.T) {
+	m := gridModel(board.DefaultRuleset())
+	m.cursorDown()
+	m.enter()
+	m.menuSelection = 3
+	m.switchToMenu()
+
+	if m.screen != "menu" || m.current != "menu" || m.menuSelection != 0 {
+		t.Errorf("switchToMenu did not select the top of the menu, got %v screen and %v view at %v",
+			m.screen, m.current, m.menuSelection)
+	}
+	if m.promptOptions != nil {
+		t.Errorf("switchToMenu did not close the prompt")
+	}
+}
+
+func TestQuitMenu(t *testing.T) {
+	m := testModel()
+	selectMenu(m, "Q - Quit Gobat")
+	if !m.quit {
+		t.Errorf("Q - Quit Gobat did not quit")
+	}
+}
@@ -2,70 +2,125 @@ package gobat
 
 import (
 	"fmt"
+	"strings"
 
 	"github.com/jroimartin/gocui"
 )
 
-var (
-	promptName      string
-	promptOptions   []string
-	promptSelection int
-)
+const cancelPrompt = "C - Cancel"
 
-// showPromptView shows the general prompt view
-func showPromptView(g *gocui.Gui) error {
-	maxX, maxY := g.Size()
+// openPrompt shows a prompt with the given title and options over the
+// current screen, returning to the view it was opened from once it is closed
+func (m *viewModel) openPrompt(name string, options []string) {
+	if m.current != "prompt" {
+		m.promptReturn = m.current
+	}
+	m.promptName = name
+	m.promptOptions = options
+	m.promptSelection = 0
+	m.current = "prompt"
+}
 
-	if v, err := g.SetView("prompt", maxX/2-7, maxY/2-4, maxX/2+7, maxY/2+4); err != nil {
-		if err != gocui.ErrUnknownView {
-			return err
+// closePrompt closes the prompt and returns to the view it was opened from
+func (m *viewModel) closePrompt() {
+	if m.current == "prompt" {
+		m.current = m.promptReturn
+	}
+	m.promptOptions = nil
+}
+
+// promptOption returns the selected option of the prompt
+func (m *viewModel) promptOption() (string, bool) {
+	if m.promptSelection >= len(m.promptOptions) {
+		return "", false
+	}
+	return m.promptOptions[m.promptSelection], true
+}
+
+// promptKeyOption returns the position of the prompt option labelled with
+// the given letter, e.g. m for "M - Miss"
+func (m *viewModel) promptKeyOption(ch rune) (int, bool) {
+	if m.current != "prompt" {
+		return 0, false
+	}
+	prefix := strings.ToUpper(string(ch)) + " - "
+	for i, option := range m.promptOptions {
+		if strings.HasPrefix(option, prefix) {
+			return i, true
 		}
-		v.Highlight = true
-		v.SelBgColor = gocui.ColorWhite
-		v.SelFgColor = gocui.ColorBlack
-	} else {
-		refreshPromptView(v)
 	}
+	return 0, false
+}
 
-	return nil
+// promptKey selects and enters the prompt option labelled with the given
+// letter, doing nothing if no option has that letter
+func (m *viewModel) promptKey(ch rune) {
+	if i, ok := m.promptKeyOption(ch); ok {
+		m.promptSelection = i
+		m.promptEnter()
+	}
 }
 
-// refreshPromptView refreshes the general prompt view
-func refreshPromptView(v *gocui.View) {
-	v.Clear()
-	for _, line := range promptOptions {
-		fmt.Fprintln(v, line)
+// promptEnter processes any enter key prompt selection
+func (m *viewModel) promptEnter() {
+	switch m.promptReturn {
+	case "menu", "menubg":
+		m.menuPromptEnter()
+	case "error":
+	default:
+		m.gridPromptEnter()
 	}
-	v.SetCursor(0, promptSelection)
+}
 
-	v.Title = promptName
-	v.Highlight = false
-	if currentView == "select" {
-		v.Highlight = true
+// escape handles the escape keybind, closing the prompt without an answer
+// or leaving the command line
+func (m *viewModel) escape() {
+	switch m.current {
+	case "prompt":
+		m.closePrompt()
+	case "command":
+		m.closeCommand()
 	}
 }
 
-// promptEnterKeySelection processes any enter key prompt selection
-func promptEnterKeySelection(g *gocui.Gui, v *gocui.View) error {
-	switch currentView {
-	case "menu", "menubg", "error":
+// showPromptView shows the general prompt view, sized to fit its options,
+// or removes it once the prompt has been closed
+func (a *App) showPromptView(g *gocui.Gui) error {
+	if a.current != "prompt" {
+		if err := g.DeleteView("prompt"); err != nil && err != gocui.ErrUnknownView {
+			return err
+		}
 		return nil
-	default:
-		if err := gridPromptEnterKeySelection(g, v); err != nil {
+	}
+
+	maxX, maxY := g.Size()
+	top := maxY/2 - len(a.promptOptions)/2 - 1
+
+	v, err := g.SetView("prompt", maxX/2-12, top, maxX/2+12, top+len(a.promptOptions)+1)
+	if err != nil {
+		if err != gocui.ErrUnknownView {
 			return err
 		}
+		v.SelBgColor = gocui.ColorWhite
+		v.SelFgColor = gocui.ColorBlack
+	}
+	// the prompt opens between key presses, so it is filled in straight away
+	a.refreshPromptView(v)
+
+	if _, err := g.SetViewOnTop("prompt"); err != nil {
+		return err
 	}
 	return nil
 }
 
-// if condition {
-// 	if v, err := g.SetViewOnTop("error"); err == nil {
-// 		do stuff
-// 	} else {
-// 		return err
-// 	}
-// } else {
-// 	if _, err := g.SetViewOnBottom("error"); err != nil {
-// 		return err
-// 	}
-// }
+// refreshPromptView refreshes the general prompt view
+func (a *App) refreshPromptView(v *gocui.View) {
+	v.Clear()
+	for _, line := range a.promptOptions {
+		fmt.Fprintln(v, line)
+	}
+	v.SetCursor(0, a.promptSelection)
+
+	v.Title = a.promptName
+	v.Highlight = a.current == "prompt"
+}
@@ -0,0 +1,121 @@
+package gobat
+
+import (
+	"testing"
+
+	"github.com/eaglerock1337/gobat/pkg/board"
+	"github.com/jroimartin/gocui"
+)
+
+func TestOpenPrompt(t *testing.T) {
+	m := testModel()
+	m.current = "C3"
+	m.openPrompt("First", []string{"A", "B"})
+	m.promptSelection = 1
+	m.openPrompt("Second", []string{"C", "D", "E"})
+
+	if m.current != "prompt" || m.promptName != "Second" || len(m.promptOptions) != 3 {
+		t.Errorf("openPrompt did not show the second prompt, got %v with %v", m.promptName, m.promptOptions)
+	}
+	if m.promptSelection != 0 {
+		t.Errorf("openPrompt did not select the first option, got %v", m.promptSelection)
+	}
+	if m.promptReturn != "C3" {
+		t.Errorf("openPrompt did not keep the view it was first opened from, got %v", m.promptReturn)
+	}
+}
+
+func TestClosePrompt(t *testing.T) {
+	m := testModel()
+	m.current = "select"
+	m.openPrompt("Test", []string{"A", "B"})
+	m.closePrompt()
+
+	if m.current != "select" {
+		t.Errorf("closePrompt did not return to the select view, got %v", m.current)
+	}
+	if m.promptOptions != nil {
+		t.Errorf("closePrompt did not clear the options, got %v", m.promptOptions)
+	}
+
+	m.current = "B2"
+	m.closePrompt()
+	if m.current != "B2" {
+		t.Errorf("closePrompt did not leave a closed prompt alone, got %v", m.current)
+	}
+}
+
+func TestEscape(t *testing.T) {
+	m := testModel()
+	m.switchToGrid()
+	m.enter()
+	m.escape()
+
+	if m.current != "select" || m.hunter.Turns != 0 {
+		t.Errorf("escape did not close the prompt without playing, got %v after %v turns", m.current, m.hunter.Turns)
+	}
+}
+
+func TestPromptOption(t *testing.T) {
+	m := testModel()
+	if _, ok := m.promptOption(); ok {
+		t.Errorf("promptOption did return an option with the prompt closed")
+	}
+
+	m.openPrompt("Test", []string{"A", "B"})
+	m.cursorDown()
+	if option, ok := m.promptOption(); !ok || option != "B" {
+		t.Errorf("promptOption did not return the selected option, got %v", option)
+	}
+}
+
+func TestPromptKey(t *testing.T) {
+	m := gridModel(board.DefaultRuleset())
+	m.current = "C4"
+	m.enter()
+	m.promptKey('x')
+	if m.current != "prompt" || m.hunter.Turns != 0 {
+		t.Errorf("promptKey did not ignore a letter without an option, got %v", m.current)
+	}
+
+	m.promptKey('m')
+	square, _ := board.SquareByString("C4")
+	if m.hunter.Turns != 1 || !m.hunter.Board.IsMiss(square) || m.current != "C4" {
+		t.Errorf("promptKey did not play a miss at C4, got %v turns", m.hunter.Turns)
+	}
+
+	m.current = "C5"
+	m.enter()
+	m.promptKey('s')
+	if m.current != "prompt" || m.promptName != "Sunk at C5" {
+		t.Errorf("promptKey did not ask which ship was sunk, got %v", m.promptName)
+	}
+	m.promptKey('c')
+	if m.current != "C5" || m.hunter.Turns != 1 {
+		t.Errorf("promptKey did not cancel the prompt, got %v", m.current)
+	}
+}
+
+func TestTypedPrompt(t *testing.T) {
+	a := NewApp()
+	a.resize(120, 40)
+	a.switchToGrid()
+	a.current = "C4"
+	a.enter()
+
+	ran := false
+	handler := a.typed('m', func(g *gocui.Gui, v *gocui.View) error {
+		ran = true
+		return nil
+	})
+	handler(nil, nil)
+	if ran || a.current != "prompt" {
+		t.Errorf("typed did not leave a prompt option to the prompt, got %v", a.current)
+	}
+
+	a.escape()
+	handler(nil, nil)
+	if !ran {
+		t.Errorf("typed did not run the handler with the prompt closed")
+	}
+}
@@ -0,0 +1,104 @@
+package gobat
+
+import (
+	"fmt"
+
+	"github.com/eaglerock1337/gobat/pkg/board"
+	"github.com/eaglerock1337/gobat/pkg/hunter"
+)
+
+const fireSalvo = "F - Fire Salvo"
+
+// salvoMode returns whether the hunter is playing under salvo rules
+func (m *viewModel) salvoMode() bool {
+	return m.hunter.Options.Salvo != 0
+}
+
+// toggleSalvoMode switches the hunter between firing one shot per turn and
+// firing one shot per ship afloat, and reports the change on the menu
+func (m *viewModel) toggleSalvoMode() {
+	if m.salvoMode() {
+		m.hunter.Options.Salvo = 0
+		m.menuMessage = "Salvo mode off: one shot per turn"
+	} else {
+		m.hunter.Options.Salvo = hunter.SalvoShips
+		m.menuMessage = "Salvo mode on: one shot per ship afloat"
+	}
+	m.resetSalvo()
+}
+
+// resetSalvo recommends a new salvo, with the result of every shot set to a miss
+func (m *viewModel) resetSalvo() {
+	m.salvoShots, m.salvoResults = nil, nil
+	if !m.salvoMode() {
+		return
+	}
+
+	m.salvoShots = m.hunter.NextSalvo()
+	m.salvoResults = make([]string, len(m.salvoShots))
+	for i := range m.salvoResults {
+		m.salvoResults[i] = "Miss"
+	}
+}
+
+// inSalvo returns whether the given square is one of the recommended salvo shots
+func (m *viewModel) inSalvo(s board.Square) bool {
+	for _, shot := range m.salvoShots {
+		if shot == s {
+			return true
+		}
+	}
+	return false
+}
+
+// salvoResultOptions returns every result a salvo shot can be given
+func (m *viewModel) salvoResultOptions() []string {
+	return append([]string{"Miss", "Hit"}, m.afloatTypes()...)
+}
+
+// cycleSalvoResult changes the result of a salvo shot to the next possible result
+func (m *viewModel) cycleSalvoResult(shot int) {
+	options := m.salvoResultOptions()
+	next := 0
+	for i, option := range options {
+		if option == m.salvoResults[shot] {
+			next = (i + 1) % len(options)
+		}
+	}
+	m.salvoResults[shot] = options[next]
+}
+
+// fireSalvoTurn plays every shot of the salvo with its chosen result
+func (m *viewModel) fireSalvoTurn() error {
+	moves := make([]hunter.Move, len(m.salvoShots))
+	for i, shot := range m.salvoShots {
+		moves[i] = hunter.Move{Square: shot, Result: m.salvoResults[i]}
+	}
+	return m.hunter.TurnSalvo(moves)
+}
+
+// salvoEnter handles enter key selection in the select view under salvo
+// rules, where each shot's result is chosen before the salvo is fired
+func (m *viewModel) salvoEnter() {
+	switch {
+	case m.gridSelection < len(m.salvoShots):
+		m.cycleSalvoResult(m.gridSelection)
+	case m.gridSelection == len(m.salvoShots):
+		if err := m.fireSalvoTurn(); err != nil {
+			m.gridMessage = fmt.Sprintf("Salvo failed: %v", err)
+			return
+		}
+		m.turnPlayed()
+	default:
+		m.gridControl(m.selectOptions()[m.gridSelection])
+	}
+}
+
+// salvoSelectOptions lists the salvo shots and their results for the select view
+func (m *viewModel) salvoSelectOptions() []string {
+	var options []string
+	for i, square := range m.salvoShots {
+		options = append(options, fmt.Sprintf("%d - %s %s", i+1, square.PrintSquare(), m.salvoResults[i]))
+	}
+	return append(options, fireSalvo)
+}
@@ -0,0 +1,57 @@
+package gobat
+
+import (
+	"testing"
+)
+
+func TestToggleSalvoMode(t *testing.T) {
+	m := testModel()
+	m.toggleSalvoMode()
+	if !m.salvoMode() || len(m.salvoShots) != len(m.hunter.Ships) {
+		t.Errorf("toggleSalvoMode did not recommend one shot per ship, got %v", m.salvoShots)
+	}
+	for _, shot := range m.salvoShots {
+		if !m.inSalvo(shot) {
+			t.Errorf("inSalvo did not find salvo shot %v", shot.PrintSquare())
+		}
+	}
+
+	m.toggleSalvoMode()
+	if m.salvoMode() || m.salvoShots != nil {
+		t.Errorf("toggleSalvoMode did not turn off salvo mode, got %v", m.salvoShots)
+	}
+}
+
+func TestCycleSalvoResult(t *testing.T) {
+	m := testModel()
+	m.toggleSalvoMode()
+	options := m.salvoResultOptions()
+
+	for _, expected := range append(options[1:], options[0]) {
+		m.cycleSalvoResult(0)
+		if m.salvoResults[0] != expected {
+			t.Errorf("cycleSalvoResult did not change the result to %v, got %v", expected, m.salvoResults[0])
+		}
+	}
+}
+
+func TestSalvoEnter(t *testing.T) {
+	m := testModel()
+	m.toggleSalvoMode()
+	m.switchToGrid()
+	shots := len(m.salvoShots)
+
+	m.enter()
+	if m.salvoResults[0] != "Hit" || m.current != "select" {
+		t.Errorf("salvoEnter did not cycle the result of the first shot, got %v", m.salvoResults[0])
+	}
+
+	if m.selectOptions()[shots] != fireSalvo {
+		t.Errorf("selectOptions did not offer to fire the salvo after the shots, got %v", m.selectOptions())
+	}
+	m.gridSelection = shots
+	m.enter()
+	if m.hunter.Turns != 1 || m.gridSelection != 0 || m.salvoResults[0] != "Miss" {
+		t.Errorf("salvoEnter did not fire the salvo and recommend the next, got %v turns", m.hunter.Turns)
+	}
+}
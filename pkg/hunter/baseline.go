@@ -0,0 +1,123 @@
+package hunter
+
+import (
+	"errors"
+	"math/rand"
+
+	"github.com/eaglerock1337/gobat/pkg/board"
+)
+
+// RandomShooter is a baseline Shooter that fires at random unshot squares.
+type RandomShooter struct {
+	Board board.Board // The board of squares already shot
+	rng   *rand.Rand
+}
+
+// HuntTarget is a baseline Shooter that hunts at random until it finds a hit,
+// then targets the squares adjacent to every hit until none are left. With
+// Parity set, it only hunts on a checkerboard pattern, as every ship is at
+// least two squares long.
+type HuntTarget struct {
+	Board   board.Board    // The board of squares already shot
+	Targets []board.Square // The stack of squares to shoot in target mode
+	Parity  bool           // Whether to hunt only on every other square
+	rng     *rand.Rand
+}
+
+// NewRandomShooter returns a RandomShooter using the given random source,
+// shooting at the board of the given rules.
+func NewRandomShooter(rng *rand.Rand, rules board.Ruleset) *RandomShooter {
+	return &RandomShooter{Board: rules.NewBoard(), rng: rng}
+}
+
+// NewHuntTarget returns a HuntTarget using the given random source,
+// shooting at the board of the given rules.
+func NewHuntTarget(rng *rand.Rand, rules board.Ruleset) *HuntTarget {
+	return &HuntTarget{Board: rules.NewBoard(), rng: rng}
+}
+
+// NewParityHuntTarget returns a HuntTarget that hunts on a checkerboard pattern.
+func NewParityHuntTarget(rng *rand.Rand, rules board.Ruleset) *HuntTarget {
+	return &HuntTarget{Board: rules.NewBoard(), Parity: true, rng: rng}
+}
+
+// randomSquare returns a random empty square on the board that passes the
+// given test, or an error if none are left.
+func randomSquare(rng *rand.Rand, b board.Board, test func(board.Square) bool) (board.Square, error) {
+	var open []board.Square
+	for _, square := range b.Squares() {
+		if b.IsEmpty(square) && test(square) {
+			open = append(open, square)
+		}
+	}
+
+	if len(open) == 0 {
+		return board.Square{}, errors.New("no squares left to shoot")
+	}
+	return open[rng.Intn(len(open))], nil
+}
+
+// recordShot marks a shot on the board, returning an error if the result is
+// invalid or the square has already been shot.
+func recordShot(b *board.Board, s board.Square, result string) error {
+	if !b.IsEmpty(s) {
+		return errors.New("square has already been shot")
+	}
+	if result == "Empty" {
+		return errors.New("result cannot be empty")
+	}
+	// the baselines never look at which ship was sunk, so a sink that the
+	// rules do not name is simply marked as a hit
+	if result == "Sunk" {
+		result = "Hit"
+	}
+	return b.SetString(s, result)
+}
+
+// NextShot returns a random square that has not yet been shot.
+func (r *RandomShooter) NextShot() (board.Square, error) {
+	return randomSquare(r.rng, r.Board, func(board.Square) bool { return true })
+}
+
+// Record marks the result of a shot on the board.
+func (r *RandomShooter) Record(s board.Square, result string) error {
+	return recordShot(&r.Board, s, result)
+}
+
+// NextShot returns the next square from the target stack, or a random square
+// to hunt with if the stack is empty.
+func (h *HuntTarget) NextShot() (board.Square, error) {
+	for len(h.Targets) > 0 {
+		square := h.Targets[len(h.Targets)-1]
+		if h.Board.IsEmpty(square) {
+			return square, nil
+		}
+		h.Targets = h.Targets[:len(h.Targets)-1]
+	}
+
+	square, err := randomSquare(h.rng, h.Board, func(s board.Square) bool {
+		return !h.Parity || (s.Letter+s.Number)%2 == 0
+	})
+	if err != nil && h.Parity { // fall back to any square once the pattern is used up
+		return randomSquare(h.rng, h.Board, func(board.Square) bool { return true })
+	}
+	return square, err
+}
+
+// Record marks the result of a shot on the board, and pushes every adjacent
+// unshot square onto the target stack after a hit.
+func (h *HuntTarget) Record(s board.Square, result string) error {
+	if err := recordShot(&h.Board, s, result); err != nil {
+		return err
+	}
+
+	if h.Board.IsHit(s) {
+		for _, direction := range directions {
+			square, err := h.Board.Square(s.Letter+direction[0], s.Number+direction[1])
+			if err == nil && h.Board.IsEmpty(square) {
+				h.Targets = append(h.Targets, square)
+			}
+		}
+	}
+	return nil
+}
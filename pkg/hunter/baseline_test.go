@@ -0,0 +1,99 @@
+package hunter
+
+import (
+	"math/rand"
+	"testing"
+
+	"github.com/eaglerock1337/gobat/pkg/board"
+)
+
+var _ Shooter = (*RandomShooter)(nil)
+var _ Shooter = (*HuntTarget)(nil)
+
+func TestRandomShooter(t *testing.T) {
+	testRandom := NewRandomShooter(rand.New(rand.NewSource(1)), board.DefaultRuleset())
+	seen := make(map[board.Square]bool)
+
+	for i := 0; i < 100; i++ {
+		shot, err := testRandom.NextShot()
+		if err != nil {
+			t.Errorf("NextShot returned an unexpected error after %v shots: %v", i, err)
+			break
+		}
+		if seen[shot] {
+			t.Errorf("NextShot returned square %v more than once", shot.PrintSquare())
+		}
+		seen[shot] = true
+
+		if err := testRandom.Record(shot, "Miss"); err != nil {
+			t.Errorf("Record returned an unexpected error: %v", err)
+		}
+	}
+
+	if shot, err := testRandom.NextShot(); err == nil {
+		t.Errorf("NextShot did not error on a full board, returned %v", shot)
+	}
+}
+
+func TestBadRecord(t *testing.T) {
+	square, _ := board.SquareByString("C3")
+	shooters := []Shooter{
+		NewRandomShooter(rand.New(rand.NewSource(1)), board.DefaultRuleset()),
+		NewHuntTarget(rand.New(rand.NewSource(1)), board.DefaultRuleset()),
+	}
+
+	for _, shooter := range shooters {
+		if err := shooter.Record(square, "Sploosh"); err == nil {
+			t.Errorf("Record did not error with an invalid result")
+		}
+		if err := shooter.Record(square, "Empty"); err == nil {
+			t.Errorf("Record did not error with an empty result")
+		}
+		shooter.Record(square, "Miss")
+		if err := shooter.Record(square, "Hit"); err == nil {
+			t.Errorf("Record did not error with a repeated shot")
+		}
+	}
+}
+
+func TestHuntTarget(t *testing.T) {
+	testHuntTarget := NewHuntTarget(rand.New(rand.NewSource(1)), board.DefaultRuleset())
+	square, _ := board.SquareByString("A1")
+
+	testHuntTarget.Record(square, "Hit")
+	if len(testHuntTarget.Targets) != 2 {
+		t.Errorf("Record did not push the 2 squares next to A1 onto the target stack, got %v", testHuntTarget.Targets)
+	}
+
+	for i := 0; i < 2; i++ {
+		shot, _ := testHuntTarget.NextShot()
+		if shot.Letter+shot.Number != 1 {
+			t.Errorf("NextShot did not target a square next to A1, got %v", shot.PrintSquare())
+		}
+		testHuntTarget.Record(shot, "Miss")
+	}
+
+	if shot, _ := testHuntTarget.NextShot(); !testHuntTarget.Board.IsEmpty(shot) {
+		t.Errorf("NextShot did not return to hunting an unshot square, got %v", shot.PrintSquare())
+	}
+}
+
+func TestParityHuntTarget(t *testing.T) {
+	testParity := NewParityHuntTarget(rand.New(rand.NewSource(1)), board.DefaultRuleset())
+
+	for i := 0; i < 50; i++ {
+		shot, err := testParity.NextShot()
+		if err != nil {
+			t.Errorf("NextShot returned an unexpected error after %v shots: %v", i, err)
+			break
+		}
+		if (shot.Letter+shot.Number)%2 != 0 {
+			t.Errorf("NextShot hunted off the checkerboard pattern at %v", shot.PrintSquare())
+		}
+		testParity.Record(shot, "Miss")
+	}
+
+	if shot, err := testParity.NextShot(); err != nil || (shot.Letter+shot.Number)%2 == 0 {
+		t.Errorf("NextShot did not fall back to the other squares once the pattern was used up, got %v: %v", shot.PrintSquare(), err)
+	}
+}
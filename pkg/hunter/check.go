@@ -0,0 +1,162 @@
+package hunter
+
+import (
+	"errors"
+	"fmt"
+
+	"github.com/eaglerock1337/gobat/pkg/board"
+)
+
+// DefaultCheckLimit is the most search steps taken to check that the board
+// is still possible, after which the board is assumed to be possible.
+// Options.CheckLimit overrides it.
+const DefaultCheckLimit = 200000
+
+// Contradiction describes the turn that left the board in an impossible
+// state, where no arrangement of the remaining ships fits every result
+// reported so far. This usually means a result was reported incorrectly.
+type Contradiction struct {
+	Turn   int    // The turn that made the board impossible
+	Move   Move   // The move played on that turn
+	Reason string // Why no arrangement of the ships fits the board
+}
+
+// Error returns a description of the contradiction.
+func (c Contradiction) Error() string {
+	return fmt.Sprintf("turn %d (%v %v) made the board impossible: %v",
+		c.Turn, c.Move.Square.PrintSquare(), c.Move.Result, c.Reason)
+}
+
+// fits returns whether the ships without a placement can be placed around
+// the occupied squares while covering every hit. Once the search runs out of
+// steps, the board is assumed to fit rather than be reported as impossible.
+func (f *fleetSearch) fits(occupied board.Bitboard) bool {
+	if f.steps < 0 {
+		return true
+	}
+
+	var hit *board.Square
+	for i := range f.hits {
+		if !occupied.Has(f.hits[i]) {
+			hit = &f.hits[i]
+			break
+		}
+	}
+
+	for ship, masks := range f.masks {
+		if f.placed[ship] {
+			continue
+		}
+
+		for i, mask := range masks {
+			if (hit != nil && !mask.Has(*hit)) || occupied.Overlaps(f.halos[ship][i]) {
+				continue
+			}
+
+			f.steps--
+			f.placed[ship] = true
+			found := f.fits(occupied.Union(mask))
+			f.placed[ship] = false
+			if found {
+				return true
+			}
+		}
+
+		// without a hit to cover, every ship has to be placed in turn, so
+		// there is no need to try placing the others first
+		if hit == nil {
+			return false
+		}
+	}
+
+	// every ship has a placement if there was nothing left to place
+	return hit == nil
+}
+
+// sinkCombinations calls the given function with the squares occupied by
+// every combination of candidates for the ambiguous sinks that do not
+// overlap (or touch, if the rules keep ships apart), stopping as soon as the
+// function returns true.
+func (h Hunter) sinkCombinations(sink int, occupied board.Bitboard, fn func(board.Bitboard) bool) bool {
+	if sink == len(h.Sinks) {
+		return fn(occupied)
+	}
+
+	for i := range h.Sinks[sink].Candidates {
+		candidate := &h.Sinks[sink].Candidates[i]
+		if occupied.Overlaps(h.pieceHalo(candidate)) {
+			continue
+		}
+		if h.sinkCombinations(sink+1, occupied.Union(pieceMask(candidate)), fn) {
+			return true
+		}
+	}
+	return false
+}
+
+// uncoverable returns a hit that no placement of any remaining ship or
+// ambiguous sink can cover, if there is one.
+func (h Hunter) uncoverable() (board.Square, bool) {
+Hit:
+	for _, hit := range h.HitStack {
+		if h.InSinks(hit) {
+			continue
+		}
+		for _, data := range h.Data {
+			for i := range data {
+				if data[i].InSquare(hit) {
+					continue Hit
+				}
+			}
+		}
+		return hit, true
+	}
+	return board.Square{}, false
+}
+
+// Check verifies that at least one arrangement of the remaining ships still
+// fits the board, where no ships overlap (or touch, if the rules keep them
+// apart), no ship sits on a miss or a sunk ship, every outstanding hit is
+// covered, and every sunk ship has a place.
+// It returns an error describing why the board is impossible, if it is.
+// Boards too large to search, or with a negative Options.CheckLimit, are
+// only checked square by square.
+func (h Hunter) Check() error {
+	for i, ship := range h.Ships {
+		if h.Data[i].Len() == 0 {
+			return fmt.Errorf("there is no room left for the %v", ship.GetType())
+		}
+	}
+
+	for _, sink := range h.Sinks {
+		if len(sink.Candidates) == 0 {
+			return fmt.Errorf("the %v sunk at %v no longer fits the hits around it",
+				sink.Ship.GetType(), sink.Square.PrintSquare())
+		}
+	}
+
+	if hit, found := h.uncoverable(); found {
+		return fmt.Errorf("no remaining ship can cover the hit at %v", hit.PrintSquare())
+	}
+
+	limit := h.Options.CheckLimit
+	if limit == 0 {
+		limit = DefaultCheckLimit
+	}
+	if limit < 0 || !h.Board.FitsBitboard() {
+		return nil
+	}
+
+	search := h.newFleetSearch(limit)
+	search.hits = h.HitStack
+	fits := h.sinkCombinations(0, board.Bitboard{}, func(occupied board.Bitboard) bool {
+		for i := range search.placed {
+			search.placed[i] = false
+		}
+		return search.fits(occupied)
+	})
+	if !fits {
+		return errors.New("no arrangement of the remaining ships fits the board")
+	}
+	return nil
+}
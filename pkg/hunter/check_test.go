@@ -0,0 +1,119 @@
+package hunter
+
+import (
+	"errors"
+	"strings"
+	"testing"
+
+	"github.com/eaglerock1337/gobat/pkg/board"
+)
+
+func TestCheck(t *testing.T) {
+	testCheck := NewHunter()
+	if err := testCheck.Check(); err != nil {
+		t.Errorf("Check returned an unexpected error for a new Hunter: %v", err)
+	}
+
+	playMoves(t, &testCheck, []string{"E5 Miss", "C4 Hit", "C6 Hit", "C5 Destroyer", "C3 Miss"})
+	if err := testCheck.Check(); err != nil || testCheck.Contradiction != nil {
+		t.Errorf("Check returned an unexpected error for a possible board: %v", err)
+	}
+}
+
+func TestContradictionNoRoom(t *testing.T) {
+	testNoRoom := NewHunter()
+	data := testNoRoom.ShipData("Carrier")
+	*data = (*data)[:1]
+	square := (*data)[0].Coords[2]
+
+	if err := testNoRoom.Turn(square, "Miss"); err != nil {
+		t.Errorf("Turn returned an unexpected error: %v", err)
+	}
+
+	if testNoRoom.Contradiction == nil || !strings.Contains(testNoRoom.Contradiction.Reason, "Carrier") {
+		t.Errorf("Turn did not find that the Carrier has no room left, got %v", testNoRoom.Contradiction)
+	}
+}
+
+func TestContradictionHits(t *testing.T) {
+	testHits := NewHunter()
+	playMoves(t, &testHits, []string{"E5 Miss", "A1 Hit", "A2 Miss", "B1 Miss", "H8 Miss"})
+
+	expected := Contradiction{Turn: 4, Move: Move{Square: board.Square{Letter: 1, Number: 0}, Result: "Miss"}}
+	if testHits.Contradiction == nil || testHits.Contradiction.Turn != expected.Turn || testHits.Contradiction.Move != expected.Move {
+		t.Errorf("Turn did not find the contradiction on turn %v, got %v", expected.Turn, testHits.Contradiction)
+	}
+
+	var err error = *testHits.Contradiction
+	var contradiction Contradiction
+	if !errors.As(err, &contradiction) || !strings.Contains(err.Error(), "A1") {
+		t.Errorf("Contradiction did not describe the uncovered hit, got %v", err)
+	}
+
+	testHits.Undo()
+	testHits.Undo()
+	if testHits.Contradiction != nil {
+		t.Errorf("Undo did not clear the contradiction, got %v", testHits.Contradiction)
+	}
+}
+
+// a sink with too few hits is rejected by Turn rather than kept as a Contradiction
+func TestContradictionSink(t *testing.T) {
+	testSink := NewHunter()
+	playMoves(t, &testSink, []string{"C4 Hit"})
+
+	square, _ := board.SquareByString("C5")
+	err := testSink.Turn(square, "Cruiser")
+	if err == nil || !strings.Contains(err.Error(), "rejected") {
+		t.Errorf("Turn did not reject a Cruiser sunk with too few hits, got %v", err)
+	}
+
+	fresh := NewHunter()
+	if err := fresh.Turn(square, "Destroyer"); err == nil || fresh.Contradiction != nil || fresh.Turns != 0 {
+		t.Errorf("Turn did not reject a Destroyer sunk without any hits, got %v", err)
+	}
+
+	if testSink.Contradiction != nil || testSink.Turns != 1 {
+		t.Errorf("Turn recorded a rejected sinking, got %v", testSink.Contradiction)
+	}
+}
+
+var exampleLoneDestroyer = board.Ruleset{
+	Name:         "Lone Destroyer",
+	Width:        10,
+	Height:       10,
+	Fleet:        []board.FleetShip{{Ship: board.Ship("Destroyer"), Length: 2, Count: 1}},
+	AnnounceShip: true,
+}
+
+func TestCheckNoTouching(t *testing.T) {
+	// both Submarines still have room after the miss, but only next to each other
+	testApart := NewHunterWithRules(exampleApart)
+	playMoves(t, &testApart, []string{"A1 Miss"})
+	if testApart.Contradiction == nil || testApart.Contradiction.Turn != 1 {
+		t.Errorf("Turn did not find that the Submarines have to touch, got %v", testApart.Contradiction)
+	}
+}
+
+func TestCheckLimit(t *testing.T) {
+	// each hit can be covered by the Destroyer, but not both at once, which
+	// only the search finds
+	testSearch := NewHunterWithRules(exampleLoneDestroyer)
+	playMoves(t, &testSearch, []string{"A1 Hit", "J10 Hit"})
+	if testSearch.Contradiction == nil || testSearch.Contradiction.Turn != 2 {
+		t.Errorf("Turn did not find that the Destroyer cannot cover both hits, got %v", testSearch.Contradiction)
+	}
+
+	testLimit := NewHunterWithRules(exampleLoneDestroyer)
+	testLimit.Options.CheckLimit = -1
+	playMoves(t, &testLimit, []string{"A1 Hit", "J10 Hit"})
+	if testLimit.Contradiction != nil {
+		t.Errorf("Turn searched the board with a negative CheckLimit, got %v", testLimit.Contradiction)
+	}
+
+	testLimit.Turn(board.Square{Letter: 1, Number: 0}, "Miss")
+	testLimit.Turn(board.Square{Letter: 0, Number: 1}, "Miss")
+	if testLimit.Contradiction == nil {
+		t.Errorf("Turn did not find the uncovered hit with a negative CheckLimit")
+	}
+}
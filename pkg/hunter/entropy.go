@@ -0,0 +1,151 @@
+package hunter
+
+import (
+	"math"
+	"sort"
+
+	"github.com/eaglerock1337/gobat/pkg/board"
+)
+
+// The results counted for each square when ranking shots by entropy. Sunk
+// results are counted separately for each type of ship when the rules
+// announce the type of a sunk ship, starting from resultSunk.
+const (
+	resultMiss = iota
+	resultHit
+	resultSunk
+)
+
+// resultSlots returns the slot each ship's sunk result is counted in, along
+// with the number of slots needed for every result.
+func (h Hunter) resultSlots() ([]int, int) {
+	slots := make([]int, len(h.Ships))
+	if !h.Rules.AnnounceShip {
+		for i := range slots {
+			slots[i] = resultSunk
+		}
+		return slots, resultSunk + 1
+	}
+
+	types := make(map[board.Ship]int)
+	for i, ship := range h.Ships {
+		if _, found := types[ship]; !found {
+			types[ship] = resultSunk + len(types)
+		}
+		slots[i] = types[ship]
+	}
+	return slots, resultSunk + len(types)
+}
+
+// entropy returns the entropy in bits of the given counts of each result.
+func entropy(counts []int) float64 {
+	total := 0
+	for _, count := range counts {
+		total += count
+	}
+
+	bits := 0.0
+	for _, count := range counts {
+		if count > 0 {
+			p := float64(count) / float64(total)
+			bits -= p * math.Log2(p)
+		}
+	}
+	return bits
+}
+
+// Information returns the information in bits expected from the result of a
+// shot at each square, indexed like the HeatMap. As every fleet configuration
+// that fits the board gives a single result for each square, the information
+// expected is the entropy of the Miss, Hit and Sunk results over randomly
+// drawn configurations, using the same samples as a sampled HeatMap. It
+// returns false if no configuration could be drawn or the board is too large
+// to sample.
+func (h Hunter) Information() ([][]float64, bool) {
+	if !h.Board.FitsBitboard() {
+		return nil, false
+	}
+
+	width, height := h.Board.Width(), h.Board.Height()
+	slots, results := h.resultSlots()
+	counts := make([][][]int, width)
+	for i := range counts {
+		counts[i] = make([][]int, height)
+		for j := range counts[i] {
+			counts[i][j] = make([]int, results)
+		}
+	}
+
+	sampler := h.newFleetSampler()
+	hits, _ := board.NewBitboard(h.HitStack)
+	drawn := h.drawSamples(sampler, func(picks []int) {
+		for ship, pick := range picks {
+			piece := &sampler.pieces[ship][pick]
+
+			// a shot sinks the ship if it is the last of its squares to be hit
+			left := sampler.masks[ship][pick].Without(hits)
+			result := resultHit
+			if left.Count() == 1 {
+				result = slots[ship]
+			}
+			for _, square := range piece.Coords {
+				if !hits.Has(square) {
+					counts[square.Letter][square.Number][result]++
+				}
+			}
+		}
+	})
+
+	if drawn == 0 {
+		return nil, false
+	}
+
+	info := make([][]float64, width)
+	for i := range info {
+		info[i] = make([]float64, height)
+		for j := range info[i] {
+			// every configuration without a ship on the square is a miss
+			counts[i][j][resultMiss] = drawn
+			for _, count := range counts[i][j][resultHit:] {
+				counts[i][j][resultMiss] -= count
+			}
+			info[i][j] = entropy(counts[i][j])
+		}
+	}
+	return info, true
+}
+
+// RankEntropy fills the Shots with the open squares expected to give the
+// most information from their result, breaking ties by their heat in the
+// HeatMap. It returns false, leaving the Shots untouched, if the information
+// could not be sampled or no open square gives any.
+func (h *Hunter) RankEntropy() bool {
+	info, ok := h.Information()
+	if !ok {
+		return false
+	}
+
+	var squares []board.Square
+	for _, square := range h.Board.Squares() {
+		if h.Board.IsEmpty(square) && info[square.Letter][square.Number] > 0 {
+			squares = append(squares, square)
+		}
+	}
+	if len(squares) == 0 {
+		return false
+	}
+
+	sort.SliceStable(squares, func(i, j int) bool {
+		a, b := squares[i], squares[j]
+		if info[a.Letter][a.Number] != info[b.Letter][b.Number] {
+			return info[a.Letter][a.Number] > info[b.Letter][b.Number]
+		}
+		return h.HeatMap.GetSquare(a) > h.HeatMap.GetSquare(b)
+	})
+
+	h.ClearShots()
+	for i := 0; i < len(squares) && i < 5; i++ {
+		h.Shots = append(h.Shots, squares[i])
+	}
+	return true
+}
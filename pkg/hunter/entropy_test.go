@@ -0,0 +1,131 @@
+package hunter
+
+import (
+	"math"
+	"math/rand"
+	"testing"
+
+	"github.com/eaglerock1337/gobat/pkg/board"
+)
+
+// destroyerHunter returns a Hunter with only the Destroyer left afloat and
+// a hit on E5, so that the Destroyer must be on one of the squares next to it.
+func destroyerHunter() Hunter {
+	h := NewHunter()
+	for _, ship := range []board.Ship{"Carrier", "Battleship", "Cruiser", "Submarine"} {
+		h.DeleteShip(ship)
+	}
+	h.Options = Options{Select: SelectEntropy, Samples: 2000, Rand: rand.New(rand.NewSource(1))}
+
+	hit, _ := board.SquareByString("E5")
+	h.Board.SetString(hit, "Hit")
+	h.AddHitStack(hit)
+	h.SeekMode = false
+	return h
+}
+
+var exampleAdjacent = []string{"E4", "E6", "D5", "F5"}
+
+func TestEntropy(t *testing.T) {
+	expected := map[[3]int]float64{
+		{10, 0, 0}: 0,
+		{5, 5, 0}:  1,
+		{1, 1, 2}:  1.5,
+		{3, 1, 0}:  -0.75*math.Log2(0.75) - 0.25*math.Log2(0.25),
+	}
+	for counts, bits := range expected {
+		if answer := entropy(counts[:]); math.Abs(answer-bits) > 1e-9 {
+			t.Errorf("entropy was incorrect for %v, got: %v, want: %v", counts, answer, bits)
+		}
+	}
+}
+
+func TestResultSlots(t *testing.T) {
+	slots, results := NewHunter().resultSlots()
+	if results != resultSunk+5 || slots[0] == slots[1] {
+		t.Errorf("resultSlots did not give each announced ship its own result, got %v of %v", slots, results)
+	}
+
+	russian, _ := board.RulesetByName("Russian")
+	slots, results = NewHunterWithRules(russian).resultSlots()
+	for _, slot := range slots {
+		if slot != resultSunk || results != resultSunk+1 {
+			t.Errorf("resultSlots did not share the Sunk result when ships are not announced, got %v of %v", slots, results)
+			break
+		}
+	}
+}
+
+func TestInformation(t *testing.T) {
+	testInfo := destroyerHunter()
+	info, ok := testInfo.Information()
+	if !ok {
+		t.Fatalf("Information did not draw any samples for the Destroyer")
+	}
+
+	for _, square := range testInfo.Board.Squares() {
+		adjacent := false
+		for _, coords := range exampleAdjacent {
+			if square.PrintSquare() == coords {
+				adjacent = true
+			}
+		}
+
+		// the Destroyer is sunk on one of the four squares, so each gives
+		// about the entropy of a one in four chance
+		bits := info[square.Letter][square.Number]
+		if adjacent && (bits < 0.7 || bits > 0.9) || !adjacent && bits != 0 {
+			t.Errorf("Information gave unexpected bits at %v: %v", square.PrintSquare(), bits)
+		}
+	}
+
+	testLarge := NewHunterWithRules(board.DefaultRuleset().WithSize(20, 20))
+	if _, ok := testLarge.Information(); ok {
+		t.Errorf("Information sampled a board too large for a Bitboard")
+	}
+}
+
+func TestRankEntropy(t *testing.T) {
+	testRank := destroyerHunter()
+	if !testRank.RankEntropy() {
+		t.Fatalf("RankEntropy did not rank any shots")
+	}
+
+	if len(testRank.Shots) != len(exampleAdjacent) {
+		t.Errorf("RankEntropy did not rank the %v squares next to the hit, got %v", len(exampleAdjacent), testRank.Shots)
+	}
+	for _, shot := range testRank.Shots {
+		found := false
+		for _, coords := range exampleAdjacent {
+			if shot.PrintSquare() == coords {
+				found = true
+			}
+		}
+		if !found {
+			t.Errorf("RankEntropy ranked shot %v that cannot sink the Destroyer", shot.PrintSquare())
+		}
+	}
+}
+
+func TestEntropyTurn(t *testing.T) {
+	testTurn := NewHunter()
+	testTurn.Options = Options{Select: SelectEntropy, Samples: 500, Rand: rand.New(rand.NewSource(1))}
+	playMoves(t, &testTurn, []string{"E5 Miss", "C3 Hit"})
+
+	if len(testTurn.Shots) != 5 {
+		t.Errorf("Turn did not rank 5 shots by entropy, got %v", testTurn.Shots)
+	}
+	for _, shot := range testTurn.Shots {
+		if !testTurn.Board.IsEmpty(shot) {
+			t.Errorf("Turn ranked shot %v that was already played", shot.PrintSquare())
+		}
+	}
+
+	// boards too large to sample fall back to ranking by heat
+	testLarge := NewHunterWithRules(board.DefaultRuleset().WithSize(20, 20))
+	testLarge.Options.Select = SelectEntropy
+	playMoves(t, &testLarge, []string{"A1 Miss"})
+	if len(testLarge.Shots) == 0 {
+		t.Errorf("Turn did not fall back to ranking by heat on a 20x20 board")
+	}
+}
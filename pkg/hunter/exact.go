@@ -0,0 +1,209 @@
+package hunter
+
+import (
+	"sort"
+
+	"github.com/eaglerock1337/gobat/pkg/board"
+)
+
+// DefaultExactLimit is the most search steps taken to count an exact HeatMap
+// when the Options do not set a limit.
+const DefaultExactLimit = 2000000
+
+// pieceMask returns the Bitboard of every square in a piece, building one
+// for pieces that were not created with a Mask.
+func pieceMask(p *board.Piece) board.Bitboard {
+	if p.IsMasked() {
+		return p.Mask
+	}
+	mask, _ := board.NewBitboard(p.Coords)
+	return mask
+}
+
+// pieceHalo returns the Bitboard of every square in a piece and, if ships may
+// not touch under the rules, every square around it. A piece fits beside the
+// ships already placed only if its halo does not overlap any of their squares.
+func (h Hunter) pieceHalo(p *board.Piece) board.Bitboard {
+	mask := pieceMask(p)
+	if !h.Rules.NoTouching {
+		return mask
+	}
+	around, _ := board.NewBitboard(h.Board.Around(*p))
+	return mask.Union(around)
+}
+
+// fleetSearch holds the state for enumerating full fleet configurations.
+type fleetSearch struct {
+	pieces [][]board.Piece    // The possible placements of each ship
+	masks  [][]board.Bitboard // The square masks of each placement
+	halos  [][]board.Bitboard // The square masks of each placement and the squares it keeps other ships from
+	placed []bool             // Whether each ship has a placement in the current configuration
+	hits   []board.Square     // The unsunk hits every configuration must cover
+	counts [][]int            // The number of configurations using each placement
+	steps  int                // The search steps remaining before giving up
+}
+
+// newFleetSearch prepares a search over the Hunter's unsunk ships and their
+// piece data, ordering the ships from the fewest placements to the most.
+func (h Hunter) newFleetSearch(limit int) *fleetSearch {
+	search := &fleetSearch{steps: limit, hits: h.openHits()}
+	for _, data := range h.Data {
+		search.pieces = append(search.pieces, data)
+	}
+	sort.SliceStable(search.pieces, func(i, j int) bool {
+		return len(search.pieces[i]) < len(search.pieces[j])
+	})
+
+	total := 0
+	for _, pieces := range search.pieces {
+		total += len(pieces)
+	}
+
+	// the masks of every ship share one allocation, as a search is prepared
+	// on every turn
+	masks := make([]board.Bitboard, total)
+	search.masks = make([][]board.Bitboard, len(search.pieces))
+	search.placed = make([]bool, len(search.pieces))
+	for i, pieces := range search.pieces {
+		search.masks[i], masks = masks[:len(pieces):len(pieces)], masks[len(pieces):]
+		for j := range pieces {
+			search.masks[i][j] = pieceMask(&pieces[j])
+		}
+	}
+
+	// ships that may touch only keep other ships off their own squares
+	search.halos = search.masks
+	if h.Rules.NoTouching {
+		search.halos = make([][]board.Bitboard, len(search.pieces))
+		for i, pieces := range search.pieces {
+			search.halos[i] = make([]board.Bitboard, len(pieces))
+			for j := range pieces {
+				search.halos[i][j] = h.pieceHalo(&pieces[j])
+			}
+		}
+	}
+	return search
+}
+
+// countPlacements prepares the search to count the configurations using
+// each placement, which only the exact HeatMap needs.
+func (f *fleetSearch) countPlacements() {
+	f.counts = make([][]int, len(f.pieces))
+	for i, pieces := range f.pieces {
+		f.counts[i] = make([]int, len(pieces))
+	}
+}
+
+// count returns the number of ways the ships without a placement can be
+// placed around the occupied squares while covering every hit, or -1 once
+// the search runs out of steps. Hits are covered first, by branching on every
+// placement that covers the first uncovered hit, so that configurations
+// missing a hit are never explored.
+func (f *fleetSearch) count(occupied board.Bitboard) int {
+	for _, hit := range f.hits {
+		if !occupied.Has(hit) {
+			return f.cover(hit, occupied)
+		}
+	}
+
+	// without hits left to cover, the search takes about as many steps as the
+	// product of the placements left, so give up early if it cannot finish
+	estimate := 1
+	for i, pieces := range f.pieces {
+		if !f.placed[i] {
+			estimate *= len(pieces)
+			if estimate > 16*f.steps {
+				return -1
+			}
+		}
+	}
+	return f.fill(0, occupied)
+}
+
+// cover counts the configurations where the given hit is covered by each
+// possible placement of a ship without a placement.
+func (f *fleetSearch) cover(hit board.Square, occupied board.Bitboard) int {
+	total := 0
+	for ship, masks := range f.masks {
+		if f.placed[ship] {
+			continue
+		}
+		for i, mask := range masks {
+			if !mask.Has(hit) || occupied.Overlaps(f.halos[ship][i]) {
+				continue
+			}
+
+			f.steps--
+			f.placed[ship] = true
+			found := f.count(occupied.Union(mask))
+			f.placed[ship] = false
+			if found < 0 || f.steps < 0 {
+				return -1
+			}
+
+			f.counts[ship][i] += found
+			total += found
+		}
+	}
+	return total
+}
+
+// fill counts the configurations of every ship from the given one onward
+// that does not have a placement, once every hit has been covered.
+func (f *fleetSearch) fill(ship int, occupied board.Bitboard) int {
+	for ship < len(f.masks) && f.placed[ship] {
+		ship++
+	}
+	if ship == len(f.masks) {
+		return 1
+	}
+
+	total := 0
+	for i, mask := range f.masks[ship] {
+		if occupied.Overlaps(f.halos[ship][i]) {
+			continue
+		}
+
+		f.steps--
+		found := f.fill(ship+1, occupied.Union(mask))
+		if found < 0 || f.steps < 0 {
+			return -1
+		}
+
+		f.counts[ship][i] += found
+		total += found
+	}
+	return total
+}
+
+// PopulateExact populates the HeatMap with the number of full fleet
+// configurations that place a ship on each square, where no ships overlap
+// (or touch, if the rules keep them apart) and every unsunk hit is covered. It returns false, leaving the HeatMap
+// untouched, if the search exceeds its limit, no configuration fits, or the
+// board is too large to search.
+func (h *Hunter) PopulateExact() bool {
+	if !h.Board.FitsBitboard() {
+		return false
+	}
+
+	limit := h.Options.ExactLimit
+	if limit <= 0 {
+		limit = DefaultExactLimit
+	}
+
+	search := h.newFleetSearch(limit)
+	search.countPlacements()
+	if search.count(board.Bitboard{}) <= 0 {
+		return false
+	}
+
+	h.HeatMap.Initialize()
+	for i, pieces := range search.pieces {
+		for j, piece := range pieces {
+			for _, square := range piece.Coords {
+				h.HeatMap[square.Letter][square.Number] += search.counts[i][j]
+			}
+		}
+	}
+	return true
+}
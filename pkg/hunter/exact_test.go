@@ -0,0 +1,126 @@
+package hunter
+
+import (
+	"testing"
+
+	"github.com/eaglerock1337/gobat/pkg/board"
+)
+
+func TestPieceMask(t *testing.T) {
+	start, _ := board.SquareByString("C4")
+	piece, _ := board.NewPiece(board.Ship("Cruiser"), start, false)
+	literal := board.Piece{Type: piece.Type, Coords: piece.Coords}
+
+	if literal.IsMasked() || pieceMask(&literal) != piece.Mask || pieceMask(&piece) != piece.Mask {
+		t.Errorf("pieceMask did not return the same Bitboard as NewPiece, got %v, want %v", pieceMask(&literal), piece.Mask)
+	}
+}
+
+// bruteForceHeat counts every pair of non-overlapping Destroyer and Cruiser
+// placements that covers the given hit.
+func bruteForceHeat(h Hunter, hit board.Square) HeatMap {
+	heat := NewHeatMap(10, 10)
+	for _, destroyer := range *h.ShipData("Destroyer") {
+		for _, cruiser := range *h.ShipData("Cruiser") {
+			if destroyer.InPiece(cruiser) || !(destroyer.InSquare(hit) || cruiser.InSquare(hit)) {
+				continue
+			}
+			heat.PopulateMap(PieceData{destroyer, cruiser}, false)
+		}
+	}
+	return heat
+}
+
+func TestPopulateExact(t *testing.T) {
+	testExact := NewHunter()
+	for _, ship := range []board.Ship{"Carrier", "Battleship", "Submarine"} {
+		testExact.DeleteShip(ship)
+	}
+
+	hit, _ := board.SquareByString("E5")
+	testExact.Board.SetString(hit, "Hit")
+	testExact.AddHitStack(hit)
+	miss, _ := board.SquareByString("E6")
+	testExact.Board.SetString(miss, "Miss")
+	testExact.ShipData("Destroyer").DeleteSquare(miss)
+	testExact.ShipData("Cruiser").DeleteSquare(miss)
+
+	if !testExact.PopulateExact() {
+		t.Errorf("PopulateExact did not finish counting 2 ships")
+	}
+
+	if expected := bruteForceHeat(testExact, hit); !testExact.HeatMap.Equal(expected) {
+		t.Errorf("PopulateExact did not match a brute force count, got:\n%v\nwant:\n%v", testExact.HeatMap, expected)
+	}
+}
+
+func TestPopulateExactLimit(t *testing.T) {
+	testLimit := NewHunter()
+	expected := testLimit.HeatMap.Clone()
+	testLimit.Options = Options{Source: HeatExact, ExactLimit: 1000}
+
+	if testLimit.PopulateExact() {
+		t.Errorf("PopulateExact finished counting a full fleet within 1000 steps")
+	}
+
+	testLimit.Refresh()
+	if !testLimit.HeatMap.Equal(expected) {
+		t.Errorf("Refresh did not fall back to summing the piece data when exact counting failed")
+	}
+}
+
+func TestPopulateExactImpossible(t *testing.T) {
+	testImpossible := NewHunter()
+	for _, ship := range []board.Ship{"Carrier", "Battleship", "Cruiser", "Submarine"} {
+		testImpossible.DeleteShip(ship)
+	}
+
+	hit, _ := board.SquareByString("A1")
+	testImpossible.AddHitStack(hit)
+	for _, coords := range []string{"A2", "B1"} {
+		miss, _ := board.SquareByString(coords)
+		testImpossible.ShipData("Destroyer").DeleteSquare(miss)
+	}
+
+	if testImpossible.PopulateExact() {
+		t.Errorf("PopulateExact found a configuration covering a hit surrounded by misses")
+	}
+}
+
+// exampleApart fits its two Submarines on a 3x1 board only at either end,
+// as ships may not touch
+var exampleApart = board.Ruleset{
+	Name:         "Apart",
+	Width:        3,
+	Height:       1,
+	Fleet:        []board.FleetShip{{Ship: board.Ship("Submarine"), Length: 1, Count: 2}},
+	AnnounceShip: true,
+	NoTouching:   true,
+}
+
+func TestPopulateExactNoTouching(t *testing.T) {
+	testApart := NewHunterWithRules(exampleApart)
+	if !testApart.PopulateExact() {
+		t.Errorf("PopulateExact did not find the Submarines at either end of the board")
+	}
+
+	if testApart.HeatMap[0][0] == 0 || testApart.HeatMap[1][0] != 0 || testApart.HeatMap[2][0] == 0 {
+		t.Errorf("PopulateExact counted Submarines touching each other, got %v", testApart.HeatMap)
+	}
+}
+
+func TestExactTurn(t *testing.T) {
+	testTurn := NewHunter()
+	testTurn.Options.Source = HeatExact
+
+	for _, move := range exampleMoves {
+		if err := testTurn.Turn(move.Square, move.Result); err != nil {
+			t.Errorf("Turn returned an unexpected error with exact counting: %v", err)
+		}
+	}
+
+	testTurn.Undo()
+	if testTurn.Options.Source != HeatExact {
+		t.Errorf("Undo did not keep the Hunter's options, got %v", testTurn.Options)
+	}
+}
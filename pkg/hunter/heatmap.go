@@ -4,21 +4,74 @@ import (
 	"github.com/eaglerock1337/gobat/pkg/board"
 )
 
-// HeatMap is a struct for holding heatmap data of a given Battleship board.
-type HeatMap [10][10]int
+// HeatMap is a struct for holding heatmap data of a given Battleship board,
+// indexed by letter and then number like the board.Board it describes.
+type HeatMap [][]int
 
-// Initialize will zero out all values in the heatmap for reuse.
-func (h *HeatMap) Initialize() {
+// NewHeatMap returns an empty heatmap of the given width and height.
+func NewHeatMap(width, height int) HeatMap {
+	heat := make(HeatMap, width)
+	for i := range heat {
+		heat[i] = make([]int, height)
+	}
+	return heat
+}
+
+// Clone returns a copy of the heatmap that shares no data with the original.
+func (h HeatMap) Clone() HeatMap {
+	clone := make(HeatMap, len(h))
+	for i := range h {
+		clone[i] = append([]int(nil), h[i]...)
+	}
+	return clone
+}
+
+// Equal returns whether both heatmaps are the same size with the same values.
+func (h HeatMap) Equal(o HeatMap) bool {
+	if len(h) != len(o) {
+		return false
+	}
 	for i := range h {
+		if len(h[i]) != len(o[i]) {
+			return false
+		}
 		for j := range h[i] {
-			h[i][j] = 0
+			if h[i][j] != o[i][j] {
+				return false
+			}
+		}
+	}
+	return true
+}
+
+// Initialize will zero out all values in the heatmap for reuse.
+func (h *HeatMap) Initialize() {
+	for i := range *h {
+		for j := range (*h)[i] {
+			(*h)[i][j] = 0
 		}
 	}
 }
 
 // AddSquare will add one to the heatmap for the given Square.
 func (h *HeatMap) AddSquare(s board.Square) {
-	h[s.Letter][s.Number]++
+	(*h)[s.Letter][s.Number]++
+}
+
+// SubtractPiece will subtract one from the heatmap for every Square of the
+// given Piece, undoing its addition by PopulateMap.
+func (h *HeatMap) SubtractPiece(piece board.Piece) {
+	for _, square := range piece.Coords {
+		(*h)[square.Letter][square.Number]--
+	}
+}
+
+// SubtractMap will subtract every Piece of the PieceData from the heatmap,
+// undoing its addition by PopulateMap.
+func (h *HeatMap) SubtractMap(p PieceData) {
+	for i := range p {
+		h.SubtractPiece(p[i])
+	}
 }
 
 // PopulateMap will add PieceData to the heatmap, optionally
@@ -28,14 +81,88 @@ func (h *HeatMap) PopulateMap(p PieceData, initialize bool) {
 		h.Initialize()
 	}
 
-	for _, piece := range p {
-		for _, square := range piece.Coords {
+	for i := range p {
+		for _, square := range p[i].Coords {
 			h.AddSquare(square)
 		}
 	}
 }
 
+// PopulateHits will add only the pieces in the PieceData that cover at
+// least one of the given hits, weighting each piece by the number of hits
+// it covers, optionally purging the existing data based on the initialize
+// boolean. This scores the squares around known hits by how likely they are
+// to finish off the ships that were hit.
+func (h *HeatMap) PopulateHits(p PieceData, hits []board.Square, initialize bool) {
+	if initialize {
+		h.Initialize()
+	}
+
+	hitMask, masked := board.NewBitboard(hits)
+	for i := range p {
+		piece := &p[i]
+		covered := 0
+		if masked && piece.IsMasked() {
+			covered = piece.Mask.Intersect(hitMask).Count()
+		} else {
+			for _, hit := range hits {
+				if piece.InSquare(hit) {
+					covered++
+				}
+			}
+		}
+		if covered == 0 {
+			continue
+		}
+
+		for _, square := range piece.Coords {
+			(*h)[square.Letter][square.Number] += covered
+		}
+	}
+}
+
+// populateHitsIndexed adds pieces the same way as PopulateHits, but finds
+// the pieces covering the hits through the given squareIndex of the
+// PieceData rather than checking every piece.
+func (h *HeatMap) populateHitsIndexed(p PieceData, index squareIndex, hits []board.Square) {
+	for k, hit := range hits {
+		for _, pos := range index[hit.Letter][hit.Number] {
+			piece := &p[pos]
+
+			// count each piece only once, at the first hit it covers
+			covered, first := 0, -1
+			for j, other := range hits {
+				if piece.InSquare(other) {
+					if first < 0 {
+						first = j
+					}
+					covered++
+				}
+			}
+			if first != k {
+				continue
+			}
+
+			for _, square := range piece.Coords {
+				(*h)[square.Letter][square.Number] += covered
+			}
+		}
+	}
+}
+
+// IsEmpty returns whether every square of the heatmap is zero.
+func (h *HeatMap) IsEmpty() bool {
+	for i := range *h {
+		for j := range (*h)[i] {
+			if (*h)[i][j] != 0 {
+				return false
+			}
+		}
+	}
+	return true
+}
+
 // GetSquare will return the value of the given Square in the heatmap.
 func (h *HeatMap) GetSquare(s board.Square) int {
-	return h[s.Letter][s.Number]
+	return (*h)[s.Letter][s.Number]
 }
@@ -6,7 +6,7 @@ import (
 	"github.com/eaglerock1337/gobat/pkg/board"
 )
 
-var testData = [10][10]int{
+var testData = HeatMap{
 	{4, 2, 5, 23, 18, 90, 2, 0, 14, 3},
 	{3, 48, 29, 2, 0, 23, 4, 8, 3, 12},
 	{45, 23, 2, 0, 0, 0, 43, 23, 1, 4},
@@ -20,7 +20,7 @@ var testData = [10][10]int{
 }
 
 func TestInitialize(t *testing.T) {
-	testHeatMap := HeatMap(testData)
+	testHeatMap := testData.Clone()
 	testHeatMap.Initialize()
 	for i := range testHeatMap {
 		for j := range testHeatMap[i] {
@@ -47,7 +47,7 @@ func TestAddSquare(t *testing.T) {
 		{Letter: 5, Number: 4}: 1,
 		{Letter: 6, Number: 3}: 0,
 	}
-	var testmap HeatMap
+	testmap := NewHeatMap(10, 10)
 
 	for _, value := range testSquares {
 		testmap.AddSquare(value)
@@ -91,7 +91,7 @@ func TestPopulateMap(t *testing.T) {
 	}
 
 	for i, init := range initializations {
-		testHeatMap := HeatMap(testData)
+		testHeatMap := testData.Clone()
 		testHeatMap.PopulateMap(exampleData, init)
 
 		for square, values := range expected {
@@ -108,8 +108,90 @@ func TestPopulateMap(t *testing.T) {
 	}
 }
 
+func TestSubtractMap(t *testing.T) {
+	exampleData := GenPieceData(board.Ship("Cruiser"))
+	testHeatMap := testData.Clone()
+	testHeatMap.PopulateMap(exampleData, false)
+	testHeatMap.SubtractMap(exampleData)
+
+	if !testHeatMap.Equal(testData) {
+		t.Errorf("SubtractMap did not undo PopulateMap, got: %v, want: %v", testHeatMap, testData)
+	}
+
+	testHeatMap.SubtractPiece(exampleData[0])
+	for _, square := range exampleData[0].Coords {
+		if testHeatMap.GetSquare(square) != testData.GetSquare(square)-1 {
+			t.Errorf("SubtractPiece did not subtract one from square %v", square.PrintSquare())
+		}
+	}
+}
+
+func TestPopulateHits(t *testing.T) {
+	var exampleData = PieceData{
+		{Type: board.Ship("Battleship"), Coords: examplePieceSquares[0]},
+		{Type: board.Ship("Battleship"), Coords: examplePieceSquares[1]},
+		{Type: board.Ship("Battleship"), Coords: examplePieceSquares[2]},
+		{Type: board.Ship("Battleship"), Coords: examplePieceSquares[3]},
+		{Type: board.Ship("Battleship"), Coords: examplePieceSquares[4]},
+	}
+	var hits = []board.Square{{Letter: 5, Number: 2}, {Letter: 5, Number: 4}}
+	var initializations = [2]bool{false, true}
+	var expected = map[board.Square][2]int{
+		{Letter: 3, Number: 1}: {45, 0},
+		{Letter: 3, Number: 2}: {30, 1},
+		{Letter: 5, Number: 2}: {26, 3},
+		{Letter: 5, Number: 4}: {3, 3},
+		{Letter: 5, Number: 6}: {5, 1},
+		{Letter: 6, Number: 2}: {30, 1},
+		{Letter: 9, Number: 2}: {8, 0},
+	}
+
+	for i, init := range initializations {
+		testHeatMap := testData.Clone()
+		testHeatMap.PopulateHits(exampleData, hits, init)
+
+		for square, values := range expected {
+			if testHeatMap[square.Letter][square.Number] != values[i] {
+				t.Errorf(
+					"PopulateHits was incorrect for square %v with initialization: %v, got: %v, want: %v",
+					square,
+					init,
+					testHeatMap[square.Letter][square.Number],
+					values[i],
+				)
+			}
+		}
+	}
+}
+
+func TestPopulateHitsIndexed(t *testing.T) {
+	exampleData := GenPieceData(board.Ship("Battleship"))
+	hits := []board.Square{{Letter: 5, Number: 2}, {Letter: 5, Number: 4}, {Letter: 2, Number: 7}}
+
+	testHeatMap := NewHeatMap(10, 10)
+	testHeatMap.populateHitsIndexed(exampleData, newSquareIndex(exampleData, 10, 10), hits)
+	expected := NewHeatMap(10, 10)
+	expected.PopulateHits(exampleData, hits, false)
+
+	if !testHeatMap.Equal(expected) {
+		t.Errorf("populateHitsIndexed did not match PopulateHits, got: %v, want: %v", testHeatMap, expected)
+	}
+}
+
+func TestIsEmpty(t *testing.T) {
+	testHeatMap := testData.Clone()
+	if testHeatMap.IsEmpty() {
+		t.Errorf("IsEmpty returned true for a populated HeatMap")
+	}
+
+	testHeatMap.Initialize()
+	if !testHeatMap.IsEmpty() {
+		t.Errorf("IsEmpty returned false for an initialized HeatMap")
+	}
+}
+
 func TestGetSquare(t *testing.T) {
-	testHeatMap := HeatMap(testData)
+	testHeatMap := testData.Clone()
 
 	for _, square := range testSquares {
 		result := testHeatMap.GetSquare(square)
@@ -0,0 +1,122 @@
+package hunter
+
+import (
+	"errors"
+	"fmt"
+
+	"github.com/eaglerock1337/gobat/pkg/board"
+)
+
+// Move is a single shot taken by the Hunter: the square shot and its result.
+// Under salvo rules, the shots of a turn are kept as consecutive moves, each
+// marked as part of the same salvo as the move before it.
+type Move struct {
+	Square board.Square `json:"square"`          // The square that was shot
+	Result string       `json:"result"`          // The result given to Turn (Miss, Hit, or a ship type)
+	Salvo  bool         `json:"salvo,omitempty"` // Whether the shot was fired with the move before it
+}
+
+// Turns splits the moves into the shots fired on each turn.
+func Turns(moves []Move) [][]Move {
+	var turns [][]Move
+	for i, move := range moves {
+		if i > 0 && move.Salvo {
+			turns[len(turns)-1] = append(turns[len(turns)-1], move)
+			continue
+		}
+		turns = append(turns, []Move{move})
+	}
+	return turns
+}
+
+// play plays the shots of a single turn, with TurnSalvo if there is more
+// than one shot.
+func (h *Hunter) play(turn []Move) error {
+	if len(turn) == 1 {
+		return h.Turn(turn[0].Square, turn[0].Result)
+	}
+	return h.TurnSalvo(turn)
+}
+
+// Replay creates a new Hunter and plays the given moves in order, returning
+// an error if any of the moves fails.
+func Replay(moves []Move) (Hunter, error) {
+	return NewHunter().replay(moves)
+}
+
+// ReplayWithRules creates a new Hunter for the given rules and plays the
+// given moves in order, returning an error if any of the moves fails.
+func ReplayWithRules(rules board.Ruleset, moves []Move) (Hunter, error) {
+	return NewHunterWithRules(rules).replay(moves)
+}
+
+// replay creates a new Hunter with the same settings as this one and plays
+// the given moves in order.
+func (h Hunter) replay(moves []Move) (Hunter, error) {
+	hunt := NewHunterWithRules(h.Rules)
+	hunt.Options = h.Options
+	hunt.Refresh()
+	hunt.Seek()
+
+	played := 0
+	for _, turn := range Turns(moves) {
+		if err := hunt.play(turn); err != nil {
+			move := turn[0]
+			return Hunter{}, fmt.Errorf("Replay failed on move %d (%s %s): %v", played+1, move.Square.PrintSquare(), move.Result, err)
+		}
+		played += len(turn)
+	}
+	return hunt, nil
+}
+
+// Undo restores the Hunter to its exact state from before the previous
+// turn by replaying every earlier move, undoing every shot of a salvo at
+// once. The undone turn can be played again with Redo until a new turn
+// is taken.
+func (h *Hunter) Undo() error {
+	length := len(h.Moves)
+	if length == 0 {
+		return errors.New("Undo failed as there are no turns to undo")
+	}
+
+	start := length - 1
+	for start > 0 && h.Moves[start].Salvo {
+		start--
+	}
+
+	last := h.Moves[start:]
+	hunt, err := h.replay(h.Moves[:start])
+	if err != nil {
+		return fmt.Errorf("Undo failed to restore the previous turn: %v", err)
+	}
+
+	hunt.undone = append(h.undone, last)
+	*h = hunt
+	return nil
+}
+
+// Redo plays the most recently undone turn again.
+func (h *Hunter) Redo() error {
+	length := len(h.undone)
+	if length == 0 {
+		return errors.New("Redo failed as there are no turns to redo")
+	}
+
+	undone := h.undone[:length-1]
+	if err := h.play(h.undone[length-1]); err != nil {
+		return fmt.Errorf("Redo failed to replay the turn: %v", err)
+	}
+
+	h.undone = undone
+	return nil
+}
+
+// CanUndo returns whether there is a turn that can be undone.
+func (h Hunter) CanUndo() bool {
+	return len(h.Moves) > 0
+}
+
+// CanRedo returns whether there is an undone turn that can be played again.
+func (h Hunter) CanRedo() bool {
+	return len(h.undone) > 0
+}
@@ -0,0 +1,133 @@
+package hunter
+
+import (
+	"testing"
+
+	"github.com/eaglerock1337/gobat/pkg/board"
+)
+
+var exampleMoves = []Move{
+	{Square: board.Square{Letter: 4, Number: 4}, Result: "Miss"},
+	{Square: board.Square{Letter: 2, Number: 3}, Result: "Hit"},
+	{Square: board.Square{Letter: 2, Number: 4}, Result: "Hit"},
+	{Square: board.Square{Letter: 2, Number: 5}, Result: "Cruiser"},
+	{Square: board.Square{Letter: 7, Number: 7}, Result: "Miss"},
+}
+
+// sameState compares the state of two Hunters that Turn is responsible for.
+func sameState(t *testing.T, got, want Hunter) {
+	t.Helper()
+
+	if got.Turns != want.Turns || got.SeekMode != want.SeekMode {
+		t.Errorf("Hunter has %v turns and seek mode %v, want %v and %v", got.Turns, got.SeekMode, want.Turns, want.SeekMode)
+	}
+	if !got.Board.Equal(want.Board) {
+		t.Errorf("Hunter board does not match:\n%v\nwant:\n%v", got.Board, want.Board)
+	}
+	if !got.HeatMap.Equal(want.HeatMap) {
+		t.Errorf("Hunter heat map does not match:\n%v\nwant:\n%v", got.HeatMap, want.HeatMap)
+	}
+	if len(got.Ships) != len(want.Ships) || len(got.HitStack) != len(want.HitStack) || len(got.Moves) != len(want.Moves) {
+		t.Errorf("Hunter ships, hit stack or moves do not match: %v %v %v, want %v %v %v",
+			got.Ships, got.HitStack, got.Moves, want.Ships, want.HitStack, want.Moves)
+	}
+	for i, data := range want.Data {
+		if i >= len(got.Data) || got.Data[i].Len() != data.Len() {
+			t.Errorf("Hunter piece data for ship %v does not match, want %v pieces", want.Ships[i], data.Len())
+		}
+	}
+	for i, shot := range want.Shots {
+		if i >= len(got.Shots) || got.Shots[i] != shot {
+			t.Errorf("Hunter shots do not match: %v, want %v", got.Shots, want.Shots)
+			break
+		}
+	}
+}
+
+func TestReplay(t *testing.T) {
+	expected := NewHunter()
+	for _, move := range exampleMoves {
+		expected.Turn(move.Square, move.Result)
+	}
+
+	testReplay, err := Replay(exampleMoves)
+	if err != nil {
+		t.Errorf("Replay returned an unexpected error: %v", err)
+	}
+	sameState(t, testReplay, expected)
+
+	badMoves := append(exampleMoves[:1:1], Move{Square: board.Square{Letter: 0, Number: 0}, Result: "Carrier"})
+	if _, err := Replay(badMoves); err == nil {
+		t.Errorf("Replay did not error with an invalid sinking")
+	}
+}
+
+func TestUndo(t *testing.T) {
+	testUndo := NewHunter()
+	testUndo.Seek()
+	start, _ := Replay(nil)
+	states := []Hunter{start}
+
+	for _, move := range exampleMoves {
+		testUndo.Turn(move.Square, move.Result)
+		state, _ := Replay(testUndo.Moves)
+		states = append(states, state)
+	}
+
+	for i := len(exampleMoves) - 1; i >= 0; i-- {
+		if err := testUndo.Undo(); err != nil {
+			t.Errorf("Undo returned an unexpected error: %v", err)
+		}
+		sameState(t, testUndo, states[i])
+	}
+
+	if len(testUndo.Ships) != 5 || testUndo.ShipData("Cruiser").Len() != 160 {
+		t.Errorf("Undo did not restore the sunk Cruiser, got %v with %v pieces", testUndo.Ships, testUndo.ShipData("Cruiser").Len())
+	}
+
+	if err := testUndo.Undo(); err == nil {
+		t.Errorf("Undo did not error with no turns to undo")
+	}
+}
+
+func TestRedo(t *testing.T) {
+	testRedo, _ := Replay(exampleMoves)
+	expected, _ := Replay(exampleMoves)
+
+	if err := testRedo.Redo(); err == nil {
+		t.Errorf("Redo did not error with no turns to redo")
+	}
+
+	for i := 0; i < 3; i++ {
+		testRedo.Undo()
+	}
+
+	for i := 0; i < 3; i++ {
+		if !testRedo.CanRedo() {
+			t.Errorf("CanRedo returned false with %v turns undone", 3-i)
+		}
+		if err := testRedo.Redo(); err != nil {
+			t.Errorf("Redo returned an unexpected error: %v", err)
+		}
+	}
+	sameState(t, testRedo, expected)
+
+	if testRedo.CanRedo() {
+		t.Errorf("CanRedo returned true after redoing every turn")
+	}
+}
+
+func TestRedoClearedByTurn(t *testing.T) {
+	testRedo, _ := Replay(exampleMoves)
+	testRedo.Undo()
+
+	square, _ := board.SquareByString("A1")
+	testRedo.Turn(square, "Miss")
+
+	if testRedo.CanRedo() {
+		t.Errorf("Turn did not clear the undone turns")
+	}
+	if !testRedo.CanUndo() {
+		t.Errorf("CanUndo returned false after taking a turn")
+	}
+}
@@ -6,11 +6,25 @@ relying mostly on the built-in input validation and error checking from the boar
 module.
 
 The PieceData type is responsible for maintaining the lists for all potential
-ship placements based on a given ship's size (from 2 to 5 spaces). The HeatMap
-type represents a simple 10x10 board of integers (similar to board.Board), but has
-built-in methods for parsing the PieceData type and populating the heat map
+ship placements based on a given ship's size (from 2 to 5 spaces). The Hunter
+keeps its own PieceData for every ship in the fleet, so ships of the same size,
+such as the Cruiser and the Submarine, are tracked and sunk separately. The HeatMap
+type represents a simple board of integers the same size as the board.Board, but
+has built-in methods for parsing the PieceData type and populating the heat map
 accordingly.
 
+By default, the heat map simply sums every possible placement of each ship on its
+own. The Options of a Hunter can instead select an exact heat map, which only counts
+full fleet configurations where no ships overlap and every unsunk hit is covered by
+a ship, so each square's heat is proportional to its true probability of holding a
+ship. When there are too many configurations to count, a sampled heat map instead
+draws random fleet configurations for a set number of samples or length of time.
+
+Shots are ranked by their heat by default, which favors the squares most likely
+to hold a ship. The Options can instead rank shots by the information expected
+from their result, which favors the squares whose Miss, Hit or Sunk result best
+narrows down the fleet configurations that are still possible.
+
 The Hunter module ties all of this together by creating a larger struct with all
 necessary variables needed to keep track of Battleship gameplay, including a board,
 heatmap, lists of data, and other variables such as the amount of turns played.
@@ -19,6 +33,27 @@ It implements three major methods to do the following:
 - Destroy ships that have been found by shooting around known squares
 - Take turns by accepting new data about the board and updating the board and piece data
 
+A Hunter plays the standard Milton Bradley fleet on a 10x10 board by default, or
+the fleet and board size of any board.Ruleset. Under rules that do not announce the type of a sunk ship, a sink
+can be reported as "Sunk", and the Hunter works out which ship it was from the
+hits around it.
+
+After every turn, the Hunter checks that some arrangement of the remaining ships
+still fits the board. If a result was reported incorrectly and none does, the turn
+that made the board impossible is kept as the Hunter's Contradiction. A ship sunk
+with too few hits around it to fit is not flagged this way, as the Hunter has no
+placement to record for it; Turn rejects the result with an error instead. The
+search behind the check can be limited or skipped with Options.CheckLimit.
+
+Every turn is kept in a history of moves, which allows turns to be undone and
+redone by replaying the history from a new Hunter. The same history is used to
+save a game to disk and resume it later.
+
+The Shooter interface describes any engine that picks shots and records their
+results, which the Hunter satisfies. Simpler baseline engines are provided
+alongside it for comparison: a RandomShooter, and the HuntTarget engine (with or
+without checkerboard parity) described in the DataGenetics analysis.
+
 I am thinking that the hitstack requires its own member methods and better error checking
 and data handling, but that can be added later on.
 */
@@ -27,85 +62,246 @@ package hunter
 import (
 	"errors"
 	"fmt"
+	"math/rand"
+	"time"
 
 	"github.com/eaglerock1337/gobat/pkg/board"
 )
 
-// These two variables allow for conversion of each square status to
-// the status string and vice-versa. This allows for statuses to be stored
-// as integers for faster lookup and comparison.
-var (
-	values = map[string]int{
-		"Empty": 0, "Miss": 1, "Destroyer": 2, "Submarine": 3,
-		"Cruiser": 4, "Battleship": 5, "Carrier": 6, "Hit": 7,
-	}
+// The four directions (up, down, left, and right) for finding adjacent squares
+var directions = [4][2]int{{0, -1}, {0, 1}, {-1, 0}, {1, 0}}
 
-	status = [8]string{
-		"Empty", "Miss", "Destroyer", "Submarine",
-		"Cruiser", "Battleship", "Carrier", "Hit",
-	}
+// HeatSource selects how the Hunter populates its HeatMap from the piece data.
+type HeatSource int
+
+const (
+	HeatSum    HeatSource = iota // Sum every possible placement of each ship independently
+	HeatExact                    // Count every full fleet configuration that fits the board
+	HeatSample                   // Count randomly drawn fleet configurations that fit the board
 )
 
-// The four directions (up, down, left, and right) for finding adjacent squares
-var directions = [4][2]int{{0, -1}, {0, 1}, {-1, 0}, {1, 0}}
+// ShotSelection selects how the Hunter ranks the shots it recommends.
+type ShotSelection int
+
+const (
+	SelectHeat    ShotSelection = iota // Rank shots by their heat in the HeatMap
+	SelectEntropy                      // Rank shots by the information expected from their result
+)
+
+// Options holds the settings that change how the Hunter chooses its shots.
+// The zero value gives the default behavior. Every option but Rand is kept
+// when the game is saved.
+type Options struct {
+	Salvo        int           `json:"salvo"`        // The shots fired each turn (zero for one, SalvoShips for one per ship)
+	Source       HeatSource    `json:"source"`       // Where the HeatMap is populated from
+	Select       ShotSelection `json:"select"`       // How the shots are ranked
+	ExactLimit   int           `json:"exactLimit"`   // The most search steps for an exact HeatMap (zero for the default)
+	Samples      int           `json:"samples"`      // The number of fleets drawn for a sampled HeatMap or ranking by entropy
+	SampleBudget time.Duration `json:"sampleBudget"` // The most time spent drawing fleets for a sampled HeatMap or ranking by entropy
+	Rand         *rand.Rand    `json:"-"`            // The random source for sampling, shared with NextSalvo (the global source if nil)
+	CheckHeat    bool          `json:"checkHeat"`    // Whether to check the summed and indexed HeatMap against every piece each turn (for debugging)
+	CheckLimit   int           `json:"checkLimit"`   // The most search steps for checking the board after each turn (zero for the default, negative to skip the search)
+}
 
 // Hunter is a struct that holds all data necessary to determine
 // the optimal gameplay of Battleship.
 type Hunter struct {
-	Turns    int                // How many turns the Hunter has used
-	Ships    []board.Ship       // The list of active unsunk ships
-	Data     map[int]*PieceData // The list of possible ship positions by size
-	Board    board.Board        // The Battleship board with known data
-	HeatMap  HeatMap            // The heat map populated from the existing piece data
-	SeekMode bool               // Whether the hunter is in Seek or Destroy mode
-	Shots    []board.Square     // The current turn's list of best squares to play
-	HitStack []board.Square     // The current number of outstanding hits
+	Turns    int            // How many turns the Hunter has used
+	Ships    []board.Ship   // The list of active unsunk ships
+	Data     []PieceData    // The list of possible positions of each ship in Ships
+	Board    board.Board    // The Battleship board with known data
+	HeatMap  HeatMap        // The heat map populated from the existing piece data
+	SeekMode bool           // Whether the hunter is in Seek or Destroy mode
+	Shots    []board.Square // The current turn's list of best squares to play
+	HitStack []board.Square // The current number of outstanding hits
+	Sinks    []Sink         // The sunk ships whose location is still ambiguous
+	Moves    []Move         // The history of every turn taken
+	Options  Options        // The settings for how shots are chosen
+	Rules    board.Ruleset  // The rules of the game being played
+
+	Contradiction *Contradiction // The first turn that left the board impossible, if any
+	undone        [][]Move       // The stack of undone turns available to redo
+	sum           HeatMap        // The sum of every placement in Data, kept up to date as placements are deleted
+	summed        int            // The number of placements in the sum
+	index         []squareIndex  // The pieces covering every square for each PieceData in Data, kept up to date as placements are deleted
+	indexed       int            // The number of placements in the index
 }
 
 // NewHunter initializes a Hunter struct with the full list of ships,
 // all possible ship locations, an empty board, and a heat map.
 func NewHunter() Hunter {
+	return NewHunterWithRules(board.DefaultRuleset())
+}
+
+// NewHunterWithRules initializes a Hunter the same way as NewHunter, but
+// for the fleet of the given rules.
+func NewHunterWithRules(rules board.Ruleset) Hunter {
 	var newHunter Hunter
-	newHunter.Ships = board.ShipTypes()
+	newHunter.Rules = rules
+	newHunter.Ships = rules.Ships()
 	newHunter.SeekMode = true
 	newHunter.Shots = make([]board.Square, 0, 5)
-	newHunter.Data = make(map[int]*PieceData)
+	newHunter.Data = make([]PieceData, 0, len(newHunter.Ships))
+	newHunter.Board = rules.NewBoard()
+	newHunter.HeatMap = NewHeatMap(rules.Width, rules.Height)
 
 	for _, ship := range newHunter.Ships {
-		if ship.GetType() != "Submarine" {
-			data := GenPieceData(ship)
-			newHunter.Data[ship.GetLength()] = &data
-		}
+		newHunter.Data = append(newHunter.Data, GenPieceDataWithRules(rules, ship))
 	}
 
 	newHunter.Refresh()
 	return newHunter
 }
 
-// DeleteShip removes a ship from the list of active ships.
+// DeleteShip removes a ship and its piece data from the list of active
+// ships. If the fleet has more than one ship of the given type, only one
+// of them is removed.
 func (h *Hunter) DeleteShip(s board.Ship) error {
 	for i, ship := range h.Ships {
 		if ship.GetType() == s.GetType() {
-			h.Ships[i] = h.Ships[len(h.Ships)-1]
-			h.Ships = h.Ships[:len(h.Ships)-1]
+			last := len(h.Ships) - 1
+			if h.isSummed() {
+				h.sum.SubtractMap(h.Data[i])
+				h.summed -= h.Data[i].Len()
+			}
+			if h.isIndexed() {
+				h.indexed -= h.Data[i].Len()
+				h.index[i] = h.index[last]
+				h.index = h.index[:last]
+			}
+
+			h.Ships[i] = h.Ships[last]
+			h.Ships = h.Ships[:last]
+			h.Data[i] = h.Data[last]
+			h.Data = h.Data[:last]
 			return nil
 		}
 	}
 	return errors.New("unable to find Ship to delete")
 }
 
-// GetValidLengths returns a slice of integers for all active ship types.
+// ShipData returns the piece data of an active ship of the given type,
+// or nil if no ship of that type is still active. As the piece data may be
+// changed through the returned pointer, the sum and index kept of it are
+// built again the next time they are needed.
+func (h *Hunter) ShipData(s board.Ship) *PieceData {
+	for i, ship := range h.Ships {
+		if ship.GetType() == s.GetType() {
+			h.invalidate()
+			return &h.Data[i]
+		}
+	}
+	return nil
+}
+
+// DeleteSquare removes all pieces that reside in a given Square from the
+// piece data of every active ship.
+func (h *Hunter) DeleteSquare(s board.Square) {
+	h.DeleteSquares([]board.Square{s})
+}
+
+// DeleteSquares removes all pieces that reside in any of the given Squares
+// from the piece data of every active ship, subtracting them from the sum
+// of every placement as they are removed. The pieces are found through the
+// index of the squares they cover, so only the removed pieces are visited.
+func (h *Hunter) DeleteSquares(squares []board.Square) {
+	var sum *HeatMap
+	if h.isSummed() {
+		sum = &h.sum
+	}
+
+	index := h.pieceIndex()
+	for i := range h.Data {
+		h.Data[i].deleteIndexed(squares, index[i], sum)
+	}
+
+	h.indexed = h.placements()
+	if sum != nil {
+		h.summed = h.indexed
+	}
+}
+
+// placements returns the number of placements left for every active ship.
+func (h Hunter) placements() int {
+	total := 0
+	for _, data := range h.Data {
+		total += data.Len()
+	}
+	return total
+}
+
+// invalidate drops the sum and index kept of the piece data, so that both
+// are built again from the piece data the next time they are needed.
+func (h *Hunter) invalidate() {
+	h.sum = nil
+	h.index = nil
+}
+
+// isSummed returns whether the sum of every placement is up to date with
+// the piece data. The Hunter's methods either keep the sum up to date or
+// invalidate it, and the number of placements also catches Data being
+// replaced or appended to directly.
+func (h Hunter) isSummed() bool {
+	return h.sum != nil && h.summed == h.placements()
+}
+
+// isIndexed returns whether the index of the squares covered by every
+// placement is up to date with the piece data, the same way as isSummed.
+func (h Hunter) isIndexed() bool {
+	return h.index != nil && len(h.index) == len(h.Data) && h.indexed == h.placements()
+}
+
+// pieceIndex returns the index of the squares covered by every placement for
+// each PieceData in Data, building it again only if it is out of date.
+func (h *Hunter) pieceIndex() []squareIndex {
+	if !h.isIndexed() {
+		h.index = make([]squareIndex, len(h.Data))
+		for i, data := range h.Data {
+			h.index[i] = newSquareIndex(data, h.Board.Width(), h.Board.Height())
+		}
+		h.indexed = h.placements()
+	}
+	return h.index
+}
+
+// sumPlacements returns the sum of every placement in the piece data,
+// summing them all again only if the kept sum is out of date. With the
+// CheckHeat option, the kept sum is checked against a full sum, panicking
+// if they do not agree.
+func (h *Hunter) sumPlacements() HeatMap {
+	if !h.isSummed() {
+		h.sum = NewHeatMap(h.Board.Width(), h.Board.Height())
+		for _, data := range h.Data {
+			h.sum.PopulateMap(data, false)
+		}
+		h.summed = h.placements()
+	}
+
+	if h.Options.CheckHeat {
+		full := NewHeatMap(h.Board.Width(), h.Board.Height())
+		for _, data := range h.Data {
+			full.PopulateMap(data, false)
+		}
+		if !full.Equal(h.sum) {
+			panic(fmt.Sprintf("the summed HeatMap on turn %d does not match the piece data", h.Turns))
+		}
+	}
+	return h.sum
+}
+
+// GetValidLengths returns a slice of integers for all active ship lengths.
+//
+// Deprecated: every ship keeps its own piece data, so the lengths are no longer
+// needed to search for ships. Use Ships with Rules.Length instead.
 func (h Hunter) GetValidLengths() []int {
 	var lengths []int
-	foundThrees := false
+	found := make(map[int]bool)
 	for _, ship := range h.Ships {
-		length := ship.GetLength()
-		if length == 3 {
-			if foundThrees {
-				continue
-			}
-			foundThrees = true
+		length := h.Rules.Length(ship)
+		if found[length] {
+			continue
 		}
+		found[length] = true
 		lengths = append(lengths, length)
 	}
 
@@ -154,12 +350,50 @@ func (h Hunter) InShots(s board.Square) bool {
 }
 
 // Refresh will refresh the HeatMap based on the updated piece data and
-// ship data.
+// ship data. If exact counting or sampling is selected but cannot produce
+// a HeatMap, the HeatMap falls back to summing the piece data. When summing
+// in Destroy mode, only the pieces covering the outstanding hits are counted,
+// weighted by how many hits they cover, and are found through the index of
+// the squares each piece covers. With the CheckHeat option, they are checked
+// against every piece, panicking if they do not agree. In Seek mode, the sum of every
+// placement is kept up to date as placements are deleted, so it is copied
+// rather than summed again.
 func (h *Hunter) Refresh() {
-	h.HeatMap.Initialize()
+	switch h.Options.Source {
+	case HeatExact:
+		if h.PopulateExact() {
+			return
+		}
+	case HeatSample:
+		if h.PopulateSample() {
+			return
+		}
+	}
 
-	for _, ship := range h.Ships {
-		h.HeatMap.PopulateMap(*h.Data[ship.GetLength()], false)
+	// in destroy mode, only count the pieces that could finish off the hits
+	if !h.SeekMode {
+		h.HeatMap.Initialize()
+		index := h.pieceIndex()
+		for i, data := range h.Data {
+			h.HeatMap.populateHitsIndexed(data, index[i], h.HitStack)
+		}
+		if h.Options.CheckHeat {
+			full := NewHeatMap(h.Board.Width(), h.Board.Height())
+			for _, data := range h.Data {
+				full.PopulateHits(data, h.HitStack, false)
+			}
+			if !full.Equal(h.HeatMap) {
+				panic(fmt.Sprintf("the indexed HeatMap on turn %d does not match the piece data", h.Turns))
+			}
+		}
+		if !h.HeatMap.IsEmpty() {
+			return
+		}
+	}
+
+	sum := h.sumPlacements()
+	for i := range h.HeatMap {
+		copy(h.HeatMap[i], sum[i])
 	}
 }
 
@@ -200,52 +434,54 @@ func (h *Hunter) ClearShots() {
 	h.Shots = make([]board.Square, 0, 5)
 }
 
-// SearchPiece searches the PieceData for the given ship for all
-// possible orientations, then intersect with the current hit stack.
-// If the function succeeds in retrieving one result, it will return
-// the piece with the location of the ship. Otherwise, the function
-// will return with an error.
-func (h Hunter) SearchPiece(sq board.Square, sh board.Ship) (board.Piece, error) {
-	var hits []board.Piece
-	length := sh.GetLength()
-Direction:
-	for _, direction := range directions {
-		let, num := direction[0], direction[1]
-
-		// Check if the piece is in the stack
-		_, err := board.SquareByValue(sq.Letter+let, sq.Number+num)
-		if err != nil {
-			continue
-		}
-		lastSquare, err := board.SquareByValue(sq.Letter+let*(length-1), sq.Number+num*(length-1))
-		if err != nil {
+// SearchPieces searches for every possible placement of the given ship
+// that covers the given square using only squares in the hit stack,
+// including placements where the square is in the middle of the ship.
+func (h Hunter) SearchPieces(sq board.Square, sh board.Ship) []board.Piece {
+	var pieces []board.Piece
+	if !h.InHitStack(sq) {
+		return pieces
+	}
+
+	length := h.Rules.Length(sh)
+	for _, horizontal := range []bool{true, false} {
+		// a ship of length one is the same piece in both directions
+		if length == 1 && !horizontal {
 			continue
 		}
-		for i := 0; i < length; i++ {
-			square, _ := board.SquareByValue(sq.Letter+let*(i), sq.Number+num*(i))
-
-			if !h.InHitStack(square) {
-				continue Direction
+	Start:
+		for offset := 0; offset < length; offset++ {
+			start, err := h.Board.Square(sq.Letter, sq.Number-offset)
+			if horizontal {
+				start, err = h.Board.Square(sq.Letter-offset, sq.Number)
+			}
+			if err != nil {
+				continue
 			}
-		}
-
-		// Create the piece to add to the list of hits
-		startSquare := sq
-		if lastSquare.Letter < sq.Letter || lastSquare.Number < sq.Number {
-			startSquare = lastSquare
-		}
 
-		var horizontal bool
-		if let != 0 {
-			horizontal = true
-		} else {
-			horizontal = false
+			piece, err := h.Rules.NewPiece(sh, start, horizontal)
+			if err != nil {
+				continue
+			}
+			for _, square := range piece.Coords {
+				if !h.InHitStack(square) {
+					continue Start
+				}
+			}
+			pieces = append(pieces, piece)
 		}
-
-		piece, _ := board.NewPiece(sh, startSquare, horizontal)
-		hits = append(hits, piece)
 	}
+	return pieces
+}
 
+// SearchPiece searches the PieceData for the given ship for all
+// possible orientations, then intersect with the current hit stack.
+// If the function succeeds in retrieving one result, it will return
+// the piece with the location of the ship. Otherwise, the function
+// will return with an error. SearchPieces returns every candidate
+// instead, which SinkShip uses to handle ambiguous sinks.
+func (h Hunter) SearchPiece(sq board.Square, sh board.Ship) (board.Piece, error) {
+	hits := h.SearchPieces(sq, sh)
 	if len(hits) == 0 {
 		return board.Piece{}, errors.New("no valid piece found in hit stack")
 	}
@@ -256,29 +492,86 @@ Direction:
 }
 
 // SinkShip will use the active hit stack, the sinking square, and the
-// type of ship sunk to find the exact location of the ship, update the
-// board and piece data, as well as delete the ship from the ship list.
+// type of ship sunk to find the location of the ship, update the board
+// and piece data, as well as delete the ship from the ship list. If the
+// ship could have more than one location, the candidates are kept in the
+// list of Sinks and the ship is placed once the candidates are narrowed
+// down to one by later turns. A ship that does not fit the hits at all is
+// rejected with an error, leaving the Hunter unchanged, rather than being
+// recorded as a Contradiction.
 func (h *Hunter) SinkShip(sq board.Square, sh board.Ship) error {
-	piece, err := h.SearchPiece(sq, sh)
-	if err != nil {
-		return fmt.Errorf("SinkShip failed due to SearchPiece not finding a piece: %v", err)
+	candidates := h.SearchPieces(sq, sh)
+	if len(candidates) == 0 {
+		return fmt.Errorf("SinkShip failed as no %v fits the hits through %v, so the sinking is rejected rather than recorded as a Contradiction",
+			sh.GetType(), sq.PrintSquare())
 	}
 
-	err = h.DeleteShip(sh)
+	err := h.DeleteShip(sh)
 	if err != nil {
 		return fmt.Errorf("SinkShip failed due to DeleteShip returning error: %v", err)
 	}
 
-	for _, square := range piece.Coords {
-		h.DelHitStack(square)
+	h.Sinks = append(h.Sinks, Sink{Ship: sh, Square: sq, Candidates: candidates})
+	h.resolveSinks()
+	return nil
+}
+
+// SunkShip works out which ship was sunk by a shot at the given square when
+// the rules do not announce the type of a sunk ship. It returns an error
+// unless exactly one type of active ship fits the hits around the square.
+func (h Hunter) SunkShip(sq board.Square) (board.Ship, error) {
+	h.HitStack = append(append([]board.Square(nil), h.HitStack...), sq)
+
+	var fits []board.Ship
+	for _, ship := range h.Ships {
+		if !shipIn(fits, ship) && len(h.SearchPieces(sq, ship)) > 0 {
+			fits = append(fits, ship)
+		}
+	}
+
+	// when ships cannot touch, every hit touching the sunk square belongs to
+	// the sunk ship, so the ship is the one as long as the group of hits
+	if len(fits) > 1 && h.Rules.NoTouching {
+		group := h.hitGroup(sq)
+		for _, ship := range fits {
+			if h.Rules.Length(ship) == len(group) {
+				return ship, nil
+			}
+		}
 	}
 
-	for _, length := range h.GetValidLengths() {
-		h.Data[length].DeletePiece(piece)
+	switch len(fits) {
+	case 0:
+		return "", fmt.Errorf("no remaining ship fits the hits around %v", sq.PrintSquare())
+	case 1:
+		return fits[0], nil
 	}
+	return "", fmt.Errorf("the ship sunk at %v could be any of %v, so it must be named", sq.PrintSquare(), fits)
+}
 
-	h.Board.SetPiece(piece)
-	return nil
+// hitGroup returns every square of the hit stack joined to the given square
+// by hits touching one another, including diagonally.
+func (h Hunter) hitGroup(sq board.Square) []board.Square {
+	group := []board.Square{sq}
+	for i := 0; i < len(group); i++ {
+		around := h.Board.Around(board.Piece{Coords: group[i : i+1]})
+		for _, square := range around {
+			if h.InHitStack(square) && !containsSquare(group, square) {
+				group = append(group, square)
+			}
+		}
+	}
+	return group
+}
+
+// shipIn returns whether the given ship is in the list of ships.
+func shipIn(ships []board.Ship, s board.Ship) bool {
+	for _, ship := range ships {
+		if ship == s {
+			return true
+		}
+	}
+	return false
 }
 
 // Seek is the main hunting routine where the HeatMap is populated with
@@ -287,40 +580,64 @@ func (h *Hunter) SinkShip(sq board.Square, sh board.Ship) error {
 func (h *Hunter) Seek() {
 	h.ClearShots()
 
-	for i := 0; i < 10; i++ {
-		for j := 0; j < 10; j++ {
-			square, _ := board.SquareByValue(i, j)
-			h.AddShot(square)
-		}
+	for _, square := range h.Board.Squares() {
+		h.AddShot(square)
 	}
 }
 
 // Destroy is the routine for sinking a ship that has been detected. Based
 // on the squares in the HitStack, all available adjacent squares are
-// checked in the HeatMap and ranked by total occurrences.
+// checked in the HeatMap and ranked by total occurrences. The HeatMap is
+// expected to have been refreshed in Destroy mode, so that only pieces
+// passing through the hits are counted. If no adjacent square can hold a
+// ship, the whole board is searched instead.
 func (h *Hunter) Destroy() {
 	h.ClearShots()
 
 	for _, hit := range h.HitStack {
 		for _, direction := range directions {
 			let, num := direction[0], direction[1]
-			square, err := board.SquareByValue(hit.Letter+let, hit.Number+num)
-			if err == nil {
-				for _, shot := range h.Shots {
-					if shot.Letter == square.Letter && shot.Number == square.Number {
-						continue
-					}
-				}
+			square, err := h.Board.Square(hit.Letter+let, hit.Number+num)
+			if err == nil && !h.InShots(square) {
 				h.AddShot(square)
 			}
 		}
 	}
+
+	// the outstanding hits may already be covered by ships that are not
+	// next to any open square, so look for them across the whole board
+	if len(h.Shots) == 0 {
+		h.Seek()
+	}
 }
 
 // Turn processes a single turn in the simulator based on the given
-// square and result. The data is pruned, heatmap updated, and ideal moves
-// given based on the mode the Hunter is currently in.
+// square and result, which is "Miss", "Hit", the type of the ship sunk, or
+// "Sunk" when the rules do not announce the type (see SunkShip). The data
+// is pruned, heatmap updated, and ideal moves given based on the mode the
+// Hunter is currently in. The board is then checked, and the first turn
+// that leaves no arrangement of the remaining ships possible is recorded as
+// the Hunter's Contradiction.
 func (h *Hunter) Turn(s board.Square, result string) error {
+	if err := h.shoot(s, result); err != nil {
+		return err
+	}
+
+	h.endTurn([]Move{{Square: s, Result: result}})
+	return nil
+}
+
+// shoot records the result of a single shot on the board, hit stack and
+// piece data, leaving the Hunter unchanged if the result is invalid.
+func (h *Hunter) shoot(s board.Square, result string) error {
+	if result == "Sunk" {
+		ship, err := h.SunkShip(s)
+		if err != nil {
+			return fmt.Errorf("Turn failed as the sunk ship is unknown: %v", err)
+		}
+		result = ship.GetType()
+	}
+
 	err := h.Board.SetString(s, result)
 	if err != nil {
 		return fmt.Errorf("Turn failed as the result was invalid: %v", err)
@@ -350,19 +667,32 @@ func (h *Hunter) Turn(s board.Square, result string) error {
 	}
 
 	if h.Board.IsMiss(s) {
-		for _, length := range h.GetValidLengths() {
-			h.Data[length].DeleteSquare(s)
-		}
+		h.DeleteSquare(s)
 	}
+	return nil
+}
 
+// endTurn updates the heat map and shots once every shot of a turn has been
+// recorded, adds the turn's moves to the history, and checks the board.
+func (h *Hunter) endTurn(moves []Move) {
 	h.Refresh()
 
-	if h.SeekMode {
+	if h.Options.Select == SelectEntropy && h.RankEntropy() {
+		// the shots are already ranked by the information expected from them
+	} else if h.SeekMode {
 		h.Seek()
 	} else {
 		h.Destroy()
 	}
 
 	h.Turns++
-	return nil
+	h.Moves = append(h.Moves, moves...)
+	h.undone = nil
+
+	// the turn is kept even if it is impossible, so it can be undone
+	if h.Contradiction == nil {
+		if err := h.Check(); err != nil {
+			h.Contradiction = &Contradiction{Turn: h.Turns, Move: moves[len(moves)-1], Reason: err.Error()}
+		}
+	}
 }
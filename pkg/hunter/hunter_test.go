@@ -25,11 +25,11 @@ func TestNewHunter(t *testing.T) {
 
 	// validate piece data
 
-	expectedData := map[int]int{2: 180, 3: 160, 4: 140, 5: 120}
+	expectedData := map[board.Ship]int{"Destroyer": 180, "Submarine": 160, "Cruiser": 160, "Battleship": 140, "Carrier": 120}
 
 	for ship, length := range expectedData {
-		if testHunter.Data[ship].Len() != length {
-			t.Errorf("PieceData for ship length %v did not return %v as expected, but %v", ship, length, testHunter.Data[ship].Len())
+		if testHunter.ShipData(ship).Len() != length {
+			t.Errorf("PieceData for ship %v did not return %v as expected, but %v", ship, length, testHunter.ShipData(ship).Len())
 		}
 	}
 
@@ -57,6 +57,89 @@ func TestNewHunter(t *testing.T) {
 	}
 }
 
+func TestNewHunterWithRules(t *testing.T) {
+	russian, _ := board.RulesetByName("Russian")
+	testHunter := NewHunterWithRules(russian)
+
+	if len(testHunter.Ships) != 10 || testHunter.Rules.Name != "Russian" {
+		t.Errorf("NewHunterWithRules did not return all 10 Russian ships, got %v", testHunter.Ships)
+	}
+
+	expectedData := map[board.Ship]int{"Battleship": 140, "Cruiser": 160, "Destroyer": 180, "Submarine": 100}
+	for ship, length := range expectedData {
+		if testHunter.ShipData(ship).Len() != length {
+			t.Errorf("PieceData for ship %v did not return %v as expected, but %v", ship, length, testHunter.ShipData(ship).Len())
+		}
+	}
+
+	lengths := testHunter.GetValidLengths()
+	if len(lengths) != 4 || lengths[0] != 4 || lengths[3] != 1 {
+		t.Errorf("GetValidLengths did not return the 4 Russian lengths, got %v", lengths)
+	}
+}
+
+func TestHunterBoardSize(t *testing.T) {
+	testLarge := NewHunterWithRules(board.DefaultRuleset().WithSize(15, 15))
+
+	if testLarge.Board.Width() != 15 || testLarge.Board.Height() != 15 || len(testLarge.HeatMap) != 15 || len(testLarge.HeatMap[14]) != 15 {
+		t.Errorf("NewHunterWithRules did not create a 15x15 board and heat map, got %vx%v", testLarge.Board.Width(), testLarge.Board.Height())
+	}
+	if testLarge.ShipData("Carrier").Len() != 2*15*11 {
+		t.Errorf("PieceData for the Carrier did not return %v as expected, but %v", 2*15*11, testLarge.ShipData("Carrier").Len())
+	}
+
+	playMoves(t, &testLarge, []string{"O15 Hit"})
+	for _, shot := range testLarge.Shots {
+		if shot.PrintSquare() != "N15" && shot.PrintSquare() != "O14" {
+			t.Errorf("Destroy did not shoot next to the hit in the corner of the board, got %v", shot.PrintSquare())
+		}
+	}
+
+	// boards too large to search fall back to summing the piece data
+	testHuge := NewHunterWithRules(board.DefaultRuleset().WithSize(20, 20))
+	testHuge.Options.Source = HeatExact
+	testHuge.Refresh()
+	if testHuge.HeatMap.IsEmpty() || testHuge.Check() != nil {
+		t.Errorf("Refresh did not fall back to summing the piece data on a 20x20 board")
+	}
+}
+
+func TestSunkShip(t *testing.T) {
+	russian, _ := board.RulesetByName("Russian")
+	testSunk := NewHunterWithRules(russian)
+	playMoves(t, &testSunk, []string{"A1 Sunk", "E5 Hit", "E6 Hit", "E7 Sunk"})
+
+	for square, ship := range map[string]string{"A1": "Submarine", "E5": "Cruiser", "E7": "Cruiser"} {
+		sq, _ := board.SquareByString(square)
+		if testSunk.Board.GetString(sq) != ship {
+			t.Errorf("Turn did not sink the %v on %v, got %v", ship, square, testSunk.Board.GetString(sq))
+		}
+	}
+
+	// no ship can touch the sunk ships, even diagonally
+	for _, square := range []string{"B2", "D4", "F8", "E8"} {
+		sq, _ := board.SquareByString(square)
+		if testSunk.HeatMap.GetSquare(sq) != 0 {
+			t.Errorf("Turn did not rule out %v next to a sunk ship, got heat %v", square, testSunk.HeatMap.GetSquare(sq))
+		}
+	}
+
+	testAmbiguous := NewHunter()
+	playMoves(t, &testAmbiguous, []string{"C4 Hit", "C5 Hit"})
+	square, _ := board.SquareByString("C6")
+	if ship, err := testAmbiguous.SunkShip(square); err == nil {
+		t.Errorf("SunkShip did not error with more than one ship fitting the hits, returned %v", ship)
+	}
+	if err := testAmbiguous.Turn(square, "Sunk"); err == nil || testAmbiguous.Turns != 2 {
+		t.Errorf("Turn did not reject an ambiguous unnamed sink: %v", err)
+	}
+
+	square, _ = board.SquareByString("C3")
+	if ship, err := testAmbiguous.SunkShip(square); err == nil {
+		t.Errorf("SunkShip did not error with more than one ship fitting C3, returned %v", ship)
+	}
+}
+
 func TestDeleteShip(t *testing.T) {
 	testDelete := NewHunter()
 	result := testDelete.DeleteShip("Battleship")
@@ -85,6 +168,59 @@ func TestBadDeleteShip(t *testing.T) {
 	}
 }
 
+func TestDeleteShipData(t *testing.T) {
+	testDelete := NewHunter()
+	testDelete.ShipData("Cruiser").Remove(0)
+	testDelete.DeleteShip("Carrier")
+
+	for i, ship := range testDelete.Ships {
+		for _, piece := range testDelete.Data[i] {
+			if piece.Type != ship {
+				t.Errorf("DeleteShip left piece data for %v with ship %v", piece.Type, ship)
+				break
+			}
+		}
+	}
+
+	if testDelete.ShipData("Carrier") != nil || testDelete.ShipData("Cruiser").Len() != 159 {
+		t.Errorf("DeleteShip did not keep the piece data of each ship separate")
+	}
+}
+
+func TestSinkSameLength(t *testing.T) {
+	testSink := NewHunter()
+	testSink.Ships = append(testSink.Ships, "Destroyer")
+	testSink.Data = append(testSink.Data, GenPieceData("Destroyer"))
+
+	playMoves(t, &testSink, []string{"C4 Hit", "C5 Hit", "C6 Cruiser", "H2 Hit", "H3 Destroyer"})
+
+	if testSink.ShipData("Cruiser") != nil || testSink.ShipData("Submarine") == nil {
+		t.Errorf("Turn did not sink only the Cruiser, got %v", testSink.Ships)
+	}
+
+	if len(testSink.Data) != 4 || testSink.ShipData("Destroyer") == nil {
+		t.Errorf("Turn did not leave the second Destroyer afloat, got %v", testSink.Ships)
+	}
+
+	expected := NewHeatMap(10, 10)
+	for _, data := range testSink.Data {
+		for _, piece := range data {
+			if piece.InList(exampleSunkSquares) {
+				t.Errorf("Turn left piece %v on a sunk ship", piece)
+			}
+		}
+		expected.PopulateMap(data, false)
+	}
+	if !testSink.HeatMap.Equal(expected) {
+		t.Errorf("Turn did not count the remaining ships once each:\n%v\nwant:\n%v", testSink.HeatMap, expected)
+	}
+}
+
+var exampleSunkSquares = []board.Square{
+	{Letter: 2, Number: 3}, {Letter: 2, Number: 4}, {Letter: 2, Number: 5},
+	{Letter: 7, Number: 1}, {Letter: 7, Number: 2},
+}
+
 func TestGetValidLengths(t *testing.T) {
 	expectedSearchResults := []int{5, 4, 3, 2}
 	testLengths := NewHunter()
@@ -274,6 +410,33 @@ func TestSearchPiece(t *testing.T) {
 	}
 }
 
+func TestSearchPiecesFleet(t *testing.T) {
+	for test, ship := range searchShips {
+		testSearchPieces := NewHunter()
+		numSquares := len(searchPieceSquares[test])
+
+		for _, square := range searchPieceSquares[test] {
+			testSearchPieces.AddShot(square)
+			testSearchPieces.AddHitStack(square)
+		}
+
+		results := testSearchPieces.SearchPieces(searchPieceSquares[test][numSquares-1], ship)
+		if len(results) != 1 {
+			t.Errorf("SearchPieces did not return exactly one piece, got %v", results)
+			continue
+		}
+		result := results[0]
+
+		if result.Type.GetType() != expectedSearchResults[test].Type.GetType() {
+			t.Errorf("SearchPieces did not return ship type %v as expected, got %v", result.Type, expectedSearchResults[test].Type)
+		}
+
+		if result.Coords[0] != expectedSearchResults[test].Coords[0] || result.Coords[1] != expectedSearchResults[test].Coords[1] {
+			t.Errorf("SearchPieces did not return result %v as expected, got %v", expectedSearchResults[test], result)
+		}
+	}
+}
+
 var badSearchSquares = [5]board.Square{
 	{Letter: 6, Number: 4},
 	{Letter: 3, Number: 3},
@@ -298,6 +461,22 @@ func TestBadSearchPiece(t *testing.T) {
 	}
 }
 
+func TestBadSearchPieces(t *testing.T) {
+	for test := range badSearchSquares {
+		testBadSearchPieces := NewHunter()
+		for _, square := range searchPieceSquares[test] {
+			testBadSearchPieces.AddShot(square)
+			testBadSearchPieces.AddHitStack(square)
+		}
+
+		results := testBadSearchPieces.SearchPieces(badSearchSquares[test], searchShips[test])
+
+		if len(results) != 0 {
+			t.Errorf("No pieces expected for search of %v, returned %v instead", badSearchSquares[test], results)
+		}
+	}
+}
+
 var dupeSearchPieceSquares = [5][]board.Square{
 	{{Letter: 0, Number: 2}, {Letter: 0, Number: 0}, {Letter: 0, Number: 1}},
 	{{Letter: 2, Number: 3}, {Letter: 2, Number: 4}, {Letter: 2, Number: 6}, {Letter: 2, Number: 7}, {Letter: 2, Number: 5}},
@@ -323,6 +502,23 @@ func TestDupeSearchPiece(t *testing.T) {
 	}
 }
 
+func TestDupeSearchPieces(t *testing.T) {
+	for test, ship := range searchShips {
+		testSearchPieces := NewHunter()
+		numSquares := len(dupeSearchPieceSquares[test])
+
+		for _, square := range dupeSearchPieceSquares[test] {
+			testSearchPieces.AddShot(square)
+			testSearchPieces.AddHitStack(square)
+		}
+
+		results := testSearchPieces.SearchPieces(dupeSearchPieceSquares[test][numSquares-1], ship)
+		if len(results) < 2 {
+			t.Errorf("SearchPieces did not return every candidate piece, got %v", results)
+		}
+	}
+}
+
 func TestSinkShip(t *testing.T) {
 	for test, ship := range searchShips {
 		testSinkShip := NewHunter()
@@ -450,6 +646,78 @@ func TestDestroy(t *testing.T) {
 	}
 }
 
+func TestRefreshDestroy(t *testing.T) {
+	testRefresh := NewHunter()
+	square, _ := board.SquareByString("E5")
+	testRefresh.Turn(square, "Hit")
+
+	for x := 0; x < 10; x++ {
+		for y := 0; y < 10; y++ {
+			inLine := x == square.Letter || y == square.Number
+			near := abs(x-square.Letter)+abs(y-square.Number) < 5
+			if heat := testRefresh.HeatMap[x][y]; (heat != 0) != (inLine && near) {
+				other, _ := board.SquareByValue(x, y)
+				t.Errorf("Refresh in destroy mode gave unexpected heat at %v: %v", other.PrintSquare(), heat)
+			}
+		}
+	}
+
+	miss, _ := board.SquareByString("E6")
+	testRefresh.Turn(miss, "Miss")
+	for _, shot := range testRefresh.Shots {
+		if testRefresh.HeatMap.GetSquare(shot) == 0 {
+			t.Errorf("Destroy recommended shot %v that no piece through the hit covers", shot.PrintSquare())
+		}
+	}
+}
+
+// fullSum returns the HeatMap from summing every placement of the Hunter's
+// piece data from scratch.
+func fullSum(h Hunter) HeatMap {
+	heat := NewHeatMap(h.Board.Width(), h.Board.Height())
+	for _, data := range h.Data {
+		heat.PopulateMap(data, false)
+	}
+	return heat
+}
+
+func TestRefreshSum(t *testing.T) {
+	testSum := NewHunter()
+	testSum.Options.CheckHeat = true
+	playMoves(t, &testSum, []string{"A1 Miss", "E5 Miss", "C3 Hit", "C4 Hit", "C5 Cruiser", "J10 Miss"})
+	if !testSum.SeekMode || !testSum.HeatMap.Equal(fullSum(testSum)) {
+		t.Errorf("Refresh did not keep the sum of every placement, got: %v, want: %v", testSum.HeatMap, fullSum(testSum))
+	}
+
+	// piece data changed directly is summed again from scratch
+	testSum.ShipData("Carrier").DeleteSquare(board.Square{Letter: 5, Number: 5})
+	testSum.Refresh()
+	if !testSum.HeatMap.Equal(fullSum(testSum)) {
+		t.Errorf("Refresh did not sum again after the piece data changed, got: %v, want: %v", testSum.HeatMap, fullSum(testSum))
+	}
+
+	russian, _ := board.RulesetByName("Russian")
+	testRussian := NewHunterWithRules(russian)
+	testRussian.Options.CheckHeat = true
+	playMoves(t, &testRussian, []string{"B2 Sunk", "E5 Hit", "E6 Sunk", "H8 Miss"})
+	if !testRussian.HeatMap.Equal(fullSum(testRussian)) {
+		t.Errorf("Refresh did not keep the sum around sunk ships that cannot touch, got: %v", testRussian.HeatMap)
+	}
+}
+
+func TestRefreshSumCheck(t *testing.T) {
+	testCheck := NewHunter()
+	testCheck.Options.CheckHeat = true
+	testCheck.sum[0][0]++
+
+	defer func() {
+		if recover() == nil {
+			t.Errorf("Refresh did not panic when the kept sum did not match the piece data")
+		}
+	}()
+	testCheck.Refresh()
+}
+
 func TestTurnMiss(t *testing.T) {
 	testTurnMiss := NewHunter()
 	square, _ := board.SquareByString("E5")
@@ -13,18 +13,36 @@ type PieceData []board.Piece
 // GenPieceData generates a complete heatdata for a given Ship.
 // I should probably add error checking into this.
 func GenPieceData(ship board.Ship) PieceData {
+	return GenPieceDataWithRules(board.DefaultRuleset(), ship)
+}
+
+// GenPieceDataWithRules generates a complete heatdata for a given Ship with
+// its length and board size under the given rules. A ship of length one is
+// only added once per square, as both directions are the same piece.
+func GenPieceDataWithRules(rules board.Ruleset, ship board.Ship) PieceData {
+	size := rules.Width
+	if rules.Height > size {
+		size = rules.Height
+	}
+
 	var data PieceData
-	for i := 0; i < 10; i++ {
-		for j := 0; j <= 10-ship.GetLength(); j++ {
-			// Add the ship horizontally, errors can be ignored due to constraints
-			hSquare, _ := board.SquareByValue(j, i)
-			hPiece, _ := board.NewPiece(ship, hSquare, true)
-			data = append(data, hPiece)
-
-			// Add the ship vertically, errors can be ignored due to constraints
-			vSquare, _ := board.SquareByValue(i, j)
-			vPiece, _ := board.NewPiece(ship, vSquare, false)
-			data = append(data, vPiece)
+	for i := 0; i < size; i++ {
+		for j := 0; j < size; j++ {
+			// Add the ship horizontally, pieces off the board are skipped by their errors
+			hSquare := board.Square{Letter: j, Number: i}
+			if hPiece, err := rules.NewPiece(ship, hSquare, true); err == nil {
+				data = append(data, hPiece)
+			}
+
+			if rules.Length(ship) == 1 {
+				continue
+			}
+
+			// Add the ship vertically, pieces off the board are skipped by their errors
+			vSquare := board.Square{Letter: i, Number: j}
+			if vPiece, err := rules.NewPiece(ship, vSquare, false); err == nil {
+				data = append(data, vPiece)
+			}
 		}
 	}
 	return data
@@ -44,22 +62,123 @@ func (p *PieceData) Remove(pos int) error {
 
 // DeleteSquare removes all Pieces that reside in a given Square.
 func (p *PieceData) DeleteSquare(square board.Square) {
+	p.DeleteSquares([]board.Square{square})
+}
+
+// DeleteSquares removes all Pieces that reside in any of the given Squares
+// in a single pass, comparing the Mask of each Piece when the squares fit in
+// a board.Bitboard rather than searching its coordinates.
+func (p *PieceData) DeleteSquares(squares []board.Square) {
+	p.DeleteSquaresWithMap(squares, nil)
+}
+
+// DeleteSquaresWithMap removes Pieces the same way as DeleteSquares, and
+// subtracts every removed Piece from the given HeatMap (if not nil), so that
+// a HeatMap populated from the PieceData stays up to date without having to
+// be populated again.
+func (p *PieceData) DeleteSquaresWithMap(squares []board.Square, heat *HeatMap) {
+	mask, masked := board.NewBitboard(squares)
 	for i := 0; i < len(*p); i++ {
-		if (*p)[i].InSquare(square) {
+		piece := &(*p)[i]
+		found := false
+		if masked && piece.IsMasked() {
+			found = piece.Mask.Overlaps(mask)
+		} else {
+			found = piece.InList(squares)
+		}
+
+		if found {
+			if heat != nil {
+				heat.SubtractPiece(*piece)
+			}
 			p.Remove(i)
-			i-- // reiterate over i since it was overwritten by DeletePiece()
+			i-- // reiterate over i since it was overwritten by Remove()
+		}
+	}
+}
+
+// deleteIndexed removes Pieces the same way as DeleteSquaresWithMap, but
+// finds them through the given squareIndex of the PieceData, which is kept
+// up to date as they are removed. Only the removed Pieces are visited.
+func (p *PieceData) deleteIndexed(squares []board.Square, index squareIndex, heat *HeatMap) {
+	for _, square := range squares {
+		for len(index[square.Letter][square.Number]) > 0 {
+			pos := index[square.Letter][square.Number][0]
+			if heat != nil {
+				heat.SubtractPiece((*p)[pos])
+			}
+
+			// Remove() moves the last piece into pos, so the index has to follow it
+			index.move((*p)[pos], pos, -1)
+			if last := len(*p) - 1; pos != last {
+				index.move((*p)[last], last, pos)
+			}
+			p.Remove(pos)
 		}
 	}
 }
 
 // DeletePiece removes all references to all squares in a given Piece's position.
 func (p *PieceData) DeletePiece(piece board.Piece) {
-	for _, square := range piece.Coords {
-		p.DeleteSquare(square)
-	}
+	p.DeleteSquares(piece.Coords)
 }
 
 // Len returns the length of the PieceData slice.
 func (p *PieceData) Len() int {
 	return len(*p)
 }
+
+// squareIndex lists, for every square of the board, the positions in a
+// PieceData of the Pieces covering that square, so that the Pieces in a
+// square are found without searching every Piece.
+type squareIndex [][][]int
+
+// newSquareIndex returns the squareIndex of the given PieceData on a board
+// of the given size.
+func newSquareIndex(p PieceData, width, height int) squareIndex {
+	index := make(squareIndex, width)
+	for i := range index {
+		index[i] = make([][]int, height)
+	}
+
+	for pos := range p {
+		for _, square := range p[pos].Coords {
+			index[square.Letter][square.Number] = append(index[square.Letter][square.Number], pos)
+		}
+	}
+	return index
+}
+
+// move changes the position of the given Piece in the index from one
+// position to another, dropping it from the index if to is negative.
+func (s squareIndex) move(piece board.Piece, from, to int) {
+	for _, square := range piece.Coords {
+		list := s[square.Letter][square.Number]
+		for i, pos := range list {
+			if pos != from {
+				continue
+			}
+
+			if to < 0 {
+				last := len(list) - 1
+				list[i] = list[last]
+				s[square.Letter][square.Number] = list[:last]
+			} else {
+				list[i] = to
+			}
+			break
+		}
+	}
+}
+
+// clone returns a deep copy of the squareIndex.
+func (s squareIndex) clone() squareIndex {
+	copied := make(squareIndex, len(s))
+	for i := range s {
+		copied[i] = make([][]int, len(s[i]))
+		for j := range s[i] {
+			copied[i][j] = append([]int(nil), s[i][j]...)
+		}
+	}
+	return copied
+}
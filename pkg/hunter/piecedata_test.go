@@ -114,6 +114,37 @@ func TestGenPieceData(t *testing.T) {
 	}
 }
 
+var exampleDataRules = map[string]int{
+	"8x8":   2 * 8 * 6,
+	"12x12": 2 * 12 * 10,
+	"15x10": 10*13 + 15*8,
+}
+
+func TestGenPieceDataWithRules(t *testing.T) {
+	russian, _ := board.RulesetByName("Russian")
+	for ship, expected := range map[board.Ship]int{"Submarine": 100, "Destroyer": 180, "Battleship": 140} {
+		answer := GenPieceDataWithRules(russian, ship)
+		if len(answer) != expected {
+			t.Errorf("GenPieceDataWithRules did not return %v pieces of the Russian %v, got %v", expected, ship, len(answer))
+		}
+		for _, piece := range answer {
+			if len(piece.Coords) != russian.Length(ship) {
+				t.Errorf("GenPieceDataWithRules returned piece %v, want length %v", piece, russian.Length(ship))
+				break
+			}
+		}
+	}
+
+	for size, expected := range exampleDataRules {
+		width, height, _ := board.ParseSize(size)
+		rules := board.DefaultRuleset().WithSize(width, height)
+		answer := GenPieceDataWithRules(rules, board.Ship("Cruiser"))
+		if len(answer) != expected {
+			t.Errorf("GenPieceDataWithRules did not return %v Cruisers on a %v board, got %v", expected, size, len(answer))
+		}
+	}
+}
+
 var exampleRemoveData = [10][]board.Square{
 	{{Letter: 0, Number: 0}, {Letter: 1, Number: 0}, {Letter: 2, Number: 0}, {Letter: 3, Number: 0}},
 	{{Letter: 3, Number: 0}, {Letter: 4, Number: 0}, {Letter: 5, Number: 0}, {Letter: 6, Number: 0}},
@@ -225,6 +256,93 @@ func TestDeleteSquare(t *testing.T) {
 	}
 }
 
+func TestDeleteSquares(t *testing.T) {
+	squaresToRemove := []board.Square{
+		{Letter: 3, Number: 0},
+		{Letter: 7, Number: 4},
+		{Letter: 6, Number: 8},
+		{Letter: 9, Number: 5},
+		{Letter: 5, Number: 3},
+	}
+	var exampleData, maskedData PieceData
+	for _, coords := range exampleRemoveData {
+		exampleData = append(exampleData, board.Piece{Type: board.Ship("Battleship"), Coords: coords})
+		mask, _ := board.NewBitboard(coords)
+		maskedData = append(maskedData, board.Piece{Type: board.Ship("Battleship"), Coords: coords, Mask: mask})
+	}
+	expected := exampleData.Len() - 6
+
+	exampleData.DeleteSquares(squaresToRemove)
+	maskedData.DeleteSquares(squaresToRemove)
+
+	for _, data := range []PieceData{exampleData, maskedData} {
+		if data.Len() != expected {
+			t.Errorf("DeleteSquares did not leave %v pieces, got %v", expected, data.Len())
+		}
+		for _, piece := range data {
+			if piece.InList(squaresToRemove) {
+				t.Errorf("DeleteSquares did not remove piece %v", piece.Coords)
+			}
+		}
+	}
+}
+
+func TestDeleteSquaresWithMap(t *testing.T) {
+	squaresToRemove := []board.Square{{Letter: 2, Number: 3}, {Letter: 7, Number: 7}, {Letter: 4, Number: 0}}
+	exampleData := GenPieceData(board.Ship("Battleship"))
+	testHeatMap := NewHeatMap(10, 10)
+	testHeatMap.PopulateMap(exampleData, false)
+
+	exampleData.DeleteSquaresWithMap(squaresToRemove, &testHeatMap)
+
+	expected := NewHeatMap(10, 10)
+	expected.PopulateMap(exampleData, false)
+	if !testHeatMap.Equal(expected) {
+		t.Errorf("DeleteSquaresWithMap did not subtract the removed pieces, got: %v, want: %v", testHeatMap, expected)
+	}
+	for _, square := range squaresToRemove {
+		if testHeatMap.GetSquare(square) != 0 {
+			t.Errorf("DeleteSquaresWithMap left heat on deleted square %v", square.PrintSquare())
+		}
+	}
+}
+
+func TestDeleteIndexed(t *testing.T) {
+	squaresToRemove := []board.Square{{Letter: 2, Number: 3}, {Letter: 7, Number: 7}, {Letter: 4, Number: 0}}
+	exampleData := GenPieceData(board.Ship("Battleship"))
+	expectedData := GenPieceData(board.Ship("Battleship"))
+	index := newSquareIndex(exampleData, 10, 10)
+	testHeatMap := NewHeatMap(10, 10)
+	testHeatMap.PopulateMap(exampleData, false)
+
+	exampleData.deleteIndexed(squaresToRemove, index, &testHeatMap)
+	expectedData.DeleteSquares(squaresToRemove)
+
+	if exampleData.Len() != expectedData.Len() {
+		t.Errorf("deleteIndexed did not remove the same pieces as DeleteSquares, got %v, want %v", exampleData.Len(), expectedData.Len())
+	}
+
+	expected := NewHeatMap(10, 10)
+	expected.PopulateMap(exampleData, false)
+	if !testHeatMap.Equal(expected) {
+		t.Errorf("deleteIndexed did not subtract the removed pieces, got: %v, want: %v", testHeatMap, expected)
+	}
+
+	expectedIndex := newSquareIndex(exampleData, 10, 10)
+	for i := range index {
+		for j := range index[i] {
+			if len(index[i][j]) != len(expectedIndex[i][j]) {
+				t.Errorf("deleteIndexed did not keep the index of square %v, got %v, want %v", board.Square{Letter: i, Number: j}.PrintSquare(), index[i][j], expectedIndex[i][j])
+			}
+			for _, pos := range index[i][j] {
+				if !exampleData[pos].InSquare(board.Square{Letter: i, Number: j}) {
+					t.Errorf("deleteIndexed left piece %v in the index of square %v", exampleData[pos], board.Square{Letter: i, Number: j}.PrintSquare())
+				}
+			}
+		}
+	}
+}
+
 func TestDeletePiece(t *testing.T) {
 	piecesToRemove := [5]board.Piece{
 		{Type: board.Ship("Cruiser"), Coords: []board.Square{{Letter: 3, Number: 0}, {Letter: 3, Number: 1}}},
@@ -284,3 +402,25 @@ func TestLen(t *testing.T) {
 		t.Errorf("Len did not return the correct PieceData slice length")
 	}
 }
+
+// BenchmarkDeleteSquares compares ruling out a miss from the Carrier's piece
+// data by checking each piece's squares against checking its mask.
+func BenchmarkDeleteSquares(b *testing.B) {
+	data := GenPieceData(board.Ship("Carrier"))
+	coords := make(PieceData, len(data))
+	for i, piece := range data {
+		coords[i] = board.Piece{Type: piece.Type, Coords: piece.Coords}
+	}
+	misses := []board.Square{{Letter: 4, Number: 4}}
+
+	for name, pieces := range map[string]PieceData{"coords": coords, "mask": data} {
+		b.Run(name, func(b *testing.B) {
+			remaining := make(PieceData, len(pieces))
+			for i := 0; i < b.N; i++ {
+				remaining = remaining[:len(pieces)]
+				copy(remaining, pieces)
+				remaining.DeleteSquares(misses)
+			}
+		})
+	}
+}
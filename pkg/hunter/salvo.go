@@ -0,0 +1,136 @@
+package hunter
+
+import (
+	"errors"
+	"fmt"
+	"sort"
+
+	"github.com/eaglerock1337/gobat/pkg/board"
+)
+
+// SalvoShips is the Salvo option for firing one shot for every ship that
+// is still afloat, as in the classic salvo rules.
+const SalvoShips = -1
+
+// SalvoSize returns the number of shots the Hunter fires together each turn.
+func (h Hunter) SalvoSize() int {
+	switch {
+	case h.Options.Salvo == SalvoShips:
+		return len(h.Ships)
+	case h.Options.Salvo > 0:
+		return h.Options.Salvo
+	}
+	return 1
+}
+
+// clone returns a copy of the Hunter that can be changed without changing
+// the original's board, ships or piece data.
+func (h Hunter) clone() Hunter {
+	copied := h
+	copied.Ships = append([]board.Ship(nil), h.Ships...)
+	copied.HitStack = append([]board.Square(nil), h.HitStack...)
+	copied.Shots = append([]board.Square(nil), h.Shots...)
+	copied.Sinks = append([]Sink(nil), h.Sinks...)
+	copied.Moves = append([]Move(nil), h.Moves...)
+	copied.Board = h.Board.Clone()
+	copied.HeatMap = h.HeatMap.Clone()
+	if h.sum != nil {
+		copied.sum = h.sum.Clone()
+	}
+	if h.index != nil {
+		copied.index = make([]squareIndex, len(h.index))
+		for i, index := range h.index {
+			copied.index[i] = index.clone()
+		}
+	}
+	copied.Data = make([]PieceData, len(h.Data))
+	for i, data := range h.Data {
+		copied.Data[i] = append(PieceData(nil), data...)
+	}
+	return copied
+}
+
+// NextSalvo returns the squares to fire together this turn. Rather than
+// taking the top squares of the heat map, which tend to share the same ship
+// placements, the shots are chosen one at a time, each assuming that the
+// shots before it missed. This gives the salvo the best chance of a hit.
+// With a sampled HeatMap, each shot draws fleets from the same Options.Rand
+// as the Hunter's own turns, so asking for a salvo changes the fleets drawn
+// on later turns.
+func (h Hunter) NextSalvo() []board.Square {
+	size := h.SalvoSize()
+	salvo := make([]board.Square, 0, size)
+	trial := h.clone()
+
+	for len(salvo) < size {
+		if len(salvo) > 0 || len(trial.Shots) == 0 {
+			if trial.SeekMode {
+				trial.Seek()
+			} else {
+				trial.Destroy()
+			}
+		}
+		if len(trial.Shots) == 0 {
+			break
+		}
+
+		shot := trial.Shots[0]
+		salvo = append(salvo, shot)
+		trial.Board.SetString(shot, "Miss")
+		trial.DeleteSquare(shot)
+		trial.Refresh()
+	}
+	return salvo
+}
+
+// resultOrder returns the order a result is recorded in within a salvo.
+// Misses and hits come first, so that every hit is on the board before the
+// ships they belong to are sunk.
+func resultOrder(result string) int {
+	switch result {
+	case "Miss":
+		return 0
+	case "Hit":
+		return 1
+	}
+	return 2
+}
+
+// TurnSalvo processes a single turn where every shot of a salvo is fired
+// together, given the square and result of each shot. The moves are kept in
+// the history in the order they were given, while the misses and hits are
+// recorded before the sinks. If any result is invalid, the Hunter is left as
+// it was before the turn and an error is returned.
+func (h *Hunter) TurnSalvo(moves []Move) error {
+	if len(moves) == 0 {
+		return errors.New("TurnSalvo failed as it was given no shots")
+	}
+
+	fired := make([]Move, len(moves))
+	copy(fired, moves)
+	for i, move := range fired {
+		for _, other := range fired[:i] {
+			if other.Square == move.Square {
+				return fmt.Errorf("TurnSalvo failed as %s was shot twice", move.Square.PrintSquare())
+			}
+		}
+		fired[i].Salvo = i > 0
+	}
+
+	salvo := make([]Move, len(fired))
+	copy(salvo, fired)
+	sort.SliceStable(salvo, func(i, j int) bool {
+		return resultOrder(salvo[i].Result) < resultOrder(salvo[j].Result)
+	})
+
+	before := h.clone()
+	for _, move := range salvo {
+		if err := h.shoot(move.Square, move.Result); err != nil {
+			*h = before
+			return fmt.Errorf("TurnSalvo failed on %s %s: %v", move.Square.PrintSquare(), move.Result, err)
+		}
+	}
+
+	h.endTurn(fired)
+	return nil
+}
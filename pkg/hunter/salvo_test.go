@@ -0,0 +1,108 @@
+package hunter
+
+import (
+	"testing"
+
+	"github.com/eaglerock1337/gobat/pkg/board"
+)
+
+func TestSalvoSize(t *testing.T) {
+	testSize := NewHunter()
+	if size := testSize.SalvoSize(); size != 1 {
+		t.Errorf("SalvoSize did not return 1 shot without salvo rules, got %v", size)
+	}
+
+	testSize.Options.Salvo = 3
+	if size := testSize.SalvoSize(); size != 3 {
+		t.Errorf("SalvoSize did not return the fixed number of shots, got %v", size)
+	}
+
+	testSize.Options.Salvo = SalvoShips
+	testSize.DeleteShip("Carrier")
+	if size := testSize.SalvoSize(); size != 4 {
+		t.Errorf("SalvoSize did not return one shot per ship afloat, got %v", size)
+	}
+}
+
+func TestNextSalvo(t *testing.T) {
+	testSalvo, _ := Replay(exampleMoves[:2])
+	testSalvo.Options.Salvo = 5
+	before := testSalvo.clone()
+
+	salvo := testSalvo.NextSalvo()
+	if len(salvo) != 5 {
+		t.Errorf("NextSalvo did not return 5 shots, got %v", salvo)
+	}
+	if len(salvo) > 0 && salvo[0] != testSalvo.Shots[0] {
+		t.Errorf("NextSalvo did not start with the best shot %v, got %v", testSalvo.Shots[0], salvo[0])
+	}
+
+	for i, shot := range salvo {
+		if testSalvo.InHitStack(shot) || !testSalvo.Board.IsEmpty(shot) {
+			t.Errorf("NextSalvo returned shot %v that was already played", shot.PrintSquare())
+		}
+		for _, other := range salvo[:i] {
+			if other == shot {
+				t.Errorf("NextSalvo returned shot %v twice", shot.PrintSquare())
+			}
+		}
+	}
+	sameState(t, testSalvo, before)
+}
+
+var exampleSalvo = []Move{
+	{Square: board.Square{Letter: 2, Number: 5}, Result: "Cruiser"},
+	{Square: board.Square{Letter: 4, Number: 4}, Result: "Miss"},
+	{Square: board.Square{Letter: 2, Number: 3}, Result: "Hit"},
+	{Square: board.Square{Letter: 2, Number: 4}, Result: "Hit"},
+}
+
+func TestTurnSalvo(t *testing.T) {
+	testSalvo := NewHunter()
+	if err := testSalvo.TurnSalvo(exampleSalvo); err != nil {
+		t.Errorf("TurnSalvo returned an unexpected error: %v", err)
+	}
+
+	if testSalvo.Turns != 1 || len(testSalvo.Moves) != 4 || len(testSalvo.Ships) != 4 {
+		t.Errorf("TurnSalvo did not play the salvo as one turn, got %v turns, moves %v", testSalvo.Turns, testSalvo.Moves)
+	}
+
+	for i, move := range testSalvo.Moves {
+		if move.Square != exampleSalvo[i].Square || move.Result != exampleSalvo[i].Result {
+			t.Errorf("TurnSalvo did not keep the moves in the order fired, got %v", testSalvo.Moves)
+			break
+		}
+	}
+
+	if turns := Turns(testSalvo.Moves); len(turns) != 1 || len(turns[0]) != 4 {
+		t.Errorf("TurnSalvo did not mark the moves as one salvo, got %v", testSalvo.Moves)
+	}
+
+	expected := testSalvo.clone()
+	if err := testSalvo.Undo(); err != nil || testSalvo.Turns != 0 || len(testSalvo.Moves) != 0 {
+		t.Errorf("Undo did not take back the whole salvo, got %v moves: %v", len(testSalvo.Moves), err)
+	}
+
+	if err := testSalvo.Redo(); err != nil {
+		t.Errorf("Redo returned an unexpected error: %v", err)
+	}
+	sameState(t, testSalvo, expected)
+}
+
+func TestTurnSalvoErrors(t *testing.T) {
+	testErrors, _ := Replay(exampleMoves[:1])
+	expected := testErrors.clone()
+
+	badSalvos := [][]Move{
+		{},
+		{exampleMoves[4], exampleSalvo[2], {Square: board.Square{Letter: 2, Number: 3}, Result: "Miss"}},
+		{exampleSalvo[2], exampleSalvo[0]},
+	}
+
+	for _, salvo := range badSalvos {
+		if err := testErrors.TurnSalvo(salvo); err == nil {
+			t.Errorf("TurnSalvo did not error with salvo %v", salvo)
+		}
+		sameState(t, testErrors, expected)
+	}
+}
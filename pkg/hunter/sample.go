@@ -0,0 +1,139 @@
+package hunter
+
+import (
+	"math/rand"
+	"time"
+
+	"github.com/eaglerock1337/gobat/pkg/board"
+)
+
+// DefaultSamples is the number of fleet configurations drawn for a sampled
+// HeatMap when the Options set neither a sample count nor a time budget.
+const DefaultSamples = 5000
+
+// sampleAttempts is how many draws are allowed per requested sample before
+// giving up, as most draws are rejected once there are hits to cover.
+const sampleAttempts = 50
+
+// fleetSampler draws random full fleet configurations from the piece data.
+type fleetSampler struct {
+	pieces [][]board.Piece    // The possible placements of each ship
+	masks  [][]board.Bitboard // The square masks of each placement
+	halos  [][]board.Bitboard // The square masks of each placement and the squares it keeps other ships from
+	hits   board.Bitboard     // The unsunk hits every configuration must cover
+	rng    *rand.Rand         // The random source, or the global source if nil
+}
+
+// newFleetSampler prepares a sampler over the Hunter's unsunk ships.
+func (h Hunter) newFleetSampler() *fleetSampler {
+	sampler := &fleetSampler{rng: h.Options.Rand}
+	sampler.hits, _ = board.NewBitboard(h.openHits())
+
+	for _, pieces := range h.Data {
+		masks := make([]board.Bitboard, len(pieces))
+		halos := masks
+		if h.Rules.NoTouching {
+			halos = make([]board.Bitboard, len(pieces))
+		}
+		for i := range pieces {
+			masks[i] = pieceMask(&pieces[i])
+			if h.Rules.NoTouching {
+				halos[i] = h.pieceHalo(&pieces[i])
+			}
+		}
+		sampler.pieces = append(sampler.pieces, pieces)
+		sampler.masks = append(sampler.masks, masks)
+		sampler.halos = append(sampler.halos, halos)
+	}
+	return sampler
+}
+
+// intn returns a random number in [0, n) from the sampler's random source.
+func (f *fleetSampler) intn(n int) int {
+	if f.rng == nil {
+		return rand.Intn(n)
+	}
+	return f.rng.Intn(n)
+}
+
+// draw places every ship at a random placement, storing the chosen placement
+// of each ship in picks. It returns false if the ships overlap, touch when
+// the rules keep them apart, or leave a hit uncovered, so that every configuration returned is equally likely.
+func (f *fleetSampler) draw(picks []int) bool {
+	var occupied board.Bitboard
+	for ship, masks := range f.masks {
+		if len(masks) == 0 {
+			return false
+		}
+
+		pick := f.intn(len(masks))
+		if occupied.Overlaps(f.halos[ship][pick]) {
+			return false
+		}
+		occupied = occupied.Union(masks[pick])
+		picks[ship] = pick
+	}
+	return occupied.Covers(f.hits)
+}
+
+// PopulateSample populates the HeatMap with the number of randomly drawn full
+// fleet configurations that place a ship on each square, where no ships
+// overlap (or touch, if the rules keep them apart) and every unsunk hit is
+// covered. Sampling stops after the number
+// of samples or the time budget in the Options, whichever comes first. It
+// returns false, leaving the HeatMap untouched, if no sample could be drawn
+// or the board is too large to sample.
+func (h *Hunter) PopulateSample() bool {
+	if !h.Board.FitsBitboard() {
+		return false
+	}
+
+	sampler := h.newFleetSampler()
+	heat := NewHeatMap(h.Board.Width(), h.Board.Height())
+	drawn := h.drawSamples(sampler, func(picks []int) {
+		for ship, pick := range picks {
+			for _, square := range sampler.pieces[ship][pick].Coords {
+				heat.AddSquare(square)
+			}
+		}
+	})
+
+	if drawn == 0 {
+		return false
+	}
+	h.HeatMap = heat
+	return true
+}
+
+// drawSamples draws random full fleet configurations from the sampler until
+// the number of samples or the time budget in the Options runs out, calling
+// the given function with the placement of each ship in every configuration
+// drawn. It returns the number of configurations drawn.
+func (h Hunter) drawSamples(sampler *fleetSampler, fn func(picks []int)) int {
+	samples, budget := h.Options.Samples, h.Options.SampleBudget
+	if samples <= 0 && budget <= 0 {
+		samples = DefaultSamples
+	}
+	attempts := samples * sampleAttempts
+
+	var deadline time.Time
+	if budget > 0 {
+		deadline = time.Now().Add(budget)
+	}
+
+	picks := make([]int, len(sampler.pieces))
+	drawn := 0
+	for i := 0; samples <= 0 || (drawn < samples && i < attempts); i++ {
+		// checking the clock is slow compared to a draw, so only check it every so often
+		if budget > 0 && i%64 == 0 && time.Now().After(deadline) {
+			break
+		}
+		if !sampler.draw(picks) {
+			continue
+		}
+
+		drawn++
+		fn(picks)
+	}
+	return drawn
+}
@@ -0,0 +1,87 @@
+package hunter
+
+import (
+	"math/rand"
+	"testing"
+	"time"
+
+	"github.com/eaglerock1337/gobat/pkg/board"
+)
+
+func TestPopulateSample(t *testing.T) {
+	testSample := NewHunter()
+	for _, ship := range []board.Ship{"Carrier", "Battleship", "Submarine"} {
+		testSample.DeleteShip(ship)
+	}
+	testSample.Options = Options{Source: HeatSample, Samples: 1000, Rand: rand.New(rand.NewSource(1))}
+
+	hit, _ := board.SquareByString("E5")
+	testSample.Board.SetString(hit, "Hit")
+	testSample.AddHitStack(hit)
+
+	if !testSample.PopulateSample() {
+		t.Errorf("PopulateSample did not draw any samples for 2 ships")
+	}
+
+	if heat := testSample.HeatMap.GetSquare(hit); heat != 1000 {
+		t.Errorf("PopulateSample did not cover the hit in every sample, got %v of 1000", heat)
+	}
+
+	expected := bruteForceHeat(testSample, hit)
+	for i := range expected {
+		for j := range expected[i] {
+			if expected[i][j] == 0 && testSample.HeatMap[i][j] != 0 {
+				square, _ := board.SquareByValue(i, j)
+				t.Errorf("PopulateSample placed a ship on impossible square %v", square.PrintSquare())
+			}
+		}
+	}
+}
+
+func TestPopulateSampleBudget(t *testing.T) {
+	testBudget := NewHunter()
+	testBudget.Options = Options{Source: HeatSample, SampleBudget: 10 * time.Millisecond}
+
+	start := time.Now()
+	if !testBudget.PopulateSample() {
+		t.Errorf("PopulateSample did not draw any samples within its time budget")
+	}
+	if elapsed := time.Since(start); elapsed > time.Second {
+		t.Errorf("PopulateSample did not stop at its time budget, took %v", elapsed)
+	}
+}
+
+func TestPopulateSampleNoTouching(t *testing.T) {
+	testApart := NewHunterWithRules(exampleApart)
+	testApart.Options = Options{Source: HeatSample, Samples: 50, Rand: rand.New(rand.NewSource(1))}
+	if !testApart.PopulateSample() {
+		t.Errorf("PopulateSample did not draw the Submarines at either end of the board")
+	}
+
+	if testApart.HeatMap[0][0] == 0 || testApart.HeatMap[1][0] != 0 || testApart.HeatMap[2][0] == 0 {
+		t.Errorf("PopulateSample drew Submarines touching each other, got %v", testApart.HeatMap)
+	}
+}
+
+func TestPopulateSampleImpossible(t *testing.T) {
+	testImpossible := NewHunter()
+	expected := testImpossible.HeatMap.Clone()
+	for _, ship := range []board.Ship{"Carrier", "Battleship", "Cruiser", "Submarine"} {
+		testImpossible.DeleteShip(ship)
+	}
+	testImpossible.Options = Options{Source: HeatSample, Samples: 10}
+
+	hit, _ := board.SquareByString("A1")
+	testImpossible.AddHitStack(hit)
+	for _, coords := range []string{"A2", "B1"} {
+		miss, _ := board.SquareByString(coords)
+		testImpossible.ShipData("Destroyer").DeleteSquare(miss)
+	}
+
+	if testImpossible.PopulateSample() {
+		t.Errorf("PopulateSample drew a configuration covering a hit surrounded by misses")
+	}
+	if !testImpossible.HeatMap.Equal(expected) {
+		t.Errorf("PopulateSample changed the HeatMap without drawing any samples")
+	}
+}
@@ -0,0 +1,155 @@
+package hunter
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"os"
+	"path/filepath"
+
+	"github.com/eaglerock1337/gobat/pkg/board"
+)
+
+// saveVersion is the current version of the saved game format.
+const saveVersion = 1
+
+// savedGame is the serialized form of a Hunter. The game is resumed by
+// replaying the moves with the saved rules and options, and the rest of the
+// data is used to verify that the replayed game matches the one that was saved.
+type savedGame struct {
+	Version  int            `json:"version"`
+	Rules    board.Ruleset  `json:"rules"`
+	Options  Options        `json:"options"`
+	Turns    int            `json:"turns"`
+	Ships    []board.Ship   `json:"ships"`
+	HitStack []board.Square `json:"hitStack"`
+	Board    board.Board    `json:"board"`
+	Moves    []Move         `json:"moves"`
+}
+
+// Save writes the Hunter's game to the given writer as JSON.
+func (h Hunter) Save(w io.Writer) error {
+	game := savedGame{
+		Version:  saveVersion,
+		Rules:    h.Rules,
+		Options:  h.Options,
+		Turns:    h.Turns,
+		Ships:    h.Ships,
+		HitStack: h.HitStack,
+		Board:    h.Board,
+		Moves:    h.Moves,
+	}
+
+	encoder := json.NewEncoder(w)
+	encoder.SetIndent("", "  ")
+	if err := encoder.Encode(game); err != nil {
+		return fmt.Errorf("Save failed to encode the game: %v", err)
+	}
+	return nil
+}
+
+// Load reads a game written by Save and resumes it by replaying its moves,
+// returning an error if the replayed game does not match the saved one. The
+// Hunter's options are restored, apart from Rand, which is left to the
+// global source. Games saved without options use the default options.
+func Load(r io.Reader) (Hunter, error) {
+	var game savedGame
+	if err := json.NewDecoder(r).Decode(&game); err != nil {
+		return Hunter{}, fmt.Errorf("Load failed to decode the game: %v", err)
+	}
+
+	if game.Version != saveVersion {
+		return Hunter{}, fmt.Errorf("Load failed due to unsupported version %d", game.Version)
+	}
+
+	// games saved before rules were configurable use the default rules
+	if game.Rules.Name == "" {
+		game.Rules = board.DefaultRuleset()
+	}
+	if err := game.Rules.Validate(); err != nil {
+		return Hunter{}, fmt.Errorf("Load failed due to invalid rules: %v", err)
+	}
+
+	hunt := NewHunterWithRules(game.Rules)
+	hunt.Options = game.Options
+	hunt, err := hunt.replay(game.Moves)
+	if err != nil {
+		return Hunter{}, fmt.Errorf("Load failed to replay the game: %v", err)
+	}
+
+	if hunt.Turns != game.Turns || !hunt.Board.Equal(game.Board) {
+		return Hunter{}, errors.New("Load failed as the board does not match the moves played")
+	}
+	if !sameShips(hunt.Ships, game.Ships) || !sameSquares(hunt.HitStack, game.HitStack) {
+		return Hunter{}, errors.New("Load failed as the ships or hit stack do not match the moves played")
+	}
+
+	return hunt, nil
+}
+
+// SaveFile saves the Hunter's game to the given file, creating any missing
+// directories along the way. An error closing the file is returned too, as
+// the game may not have been fully written.
+func (h Hunter) SaveFile(path string) error {
+	if err := os.MkdirAll(filepath.Dir(path), 0755); err != nil {
+		return fmt.Errorf("SaveFile failed to create the directory: %v", err)
+	}
+
+	file, err := os.Create(path)
+	if err != nil {
+		return fmt.Errorf("SaveFile failed to create the file: %v", err)
+	}
+
+	if err := h.Save(file); err != nil {
+		file.Close()
+		return err
+	}
+	if err := file.Close(); err != nil {
+		return fmt.Errorf("SaveFile failed to close the file: %v", err)
+	}
+	return nil
+}
+
+// LoadFile loads a Hunter's game from the given file.
+func LoadFile(path string) (Hunter, error) {
+	file, err := os.Open(path)
+	if err != nil {
+		return Hunter{}, fmt.Errorf("LoadFile failed to open the file: %v", err)
+	}
+	defer file.Close()
+
+	return Load(file)
+}
+
+// sameShips returns whether two lists hold the same ships in any order.
+func sameShips(a, b []board.Ship) bool {
+	if len(a) != len(b) {
+		return false
+	}
+	counts := make(map[board.Ship]int)
+	for _, ship := range a {
+		counts[ship]++
+	}
+	for _, ship := range b {
+		counts[ship]--
+		if counts[ship] < 0 {
+			return false
+		}
+	}
+	return true
+}
+
+// sameSquares returns whether two lists hold the same squares in any order.
+func sameSquares(a, b []board.Square) bool {
+	if len(a) != len(b) {
+		return false
+	}
+	stack := HitStack(a)
+	for _, square := range b {
+		if !stack.InStack(square) {
+			return false
+		}
+	}
+	return true
+}
@@ -0,0 +1,102 @@
+package hunter
+
+import (
+	"bytes"
+	"math/rand"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/eaglerock1337/gobat/pkg/board"
+)
+
+func TestSaveLoad(t *testing.T) {
+	testSave, _ := Replay(exampleMoves[:3])
+	var buffer bytes.Buffer
+
+	if err := testSave.Save(&buffer); err != nil {
+		t.Errorf("Save returned an unexpected error: %v", err)
+	}
+
+	if !strings.Contains(buffer.String(), `"square": "C4"`) {
+		t.Errorf("Save did not write squares as coordinates:\n%v", buffer.String())
+	}
+
+	testLoad, err := Load(&buffer)
+	if err != nil {
+		t.Errorf("Load returned an unexpected error: %v", err)
+	}
+	sameState(t, testLoad, testSave)
+}
+
+func TestSaveLoadRules(t *testing.T) {
+	russian, _ := board.RulesetByName("Russian")
+	testSave, _ := ReplayWithRules(russian, []Move{{Square: board.Square{Letter: 0, Number: 0}, Result: "Sunk"}})
+	var buffer bytes.Buffer
+	testSave.Save(&buffer)
+
+	testLoad, err := Load(&buffer)
+	if err != nil {
+		t.Errorf("Load returned an unexpected error with Russian rules: %v", err)
+	}
+	if testLoad.Rules.Name != "Russian" || len(testLoad.Ships) != 9 {
+		t.Errorf("Load did not restore the Russian rules, got %v with ships %v", testLoad.Rules.Name, testLoad.Ships)
+	}
+}
+
+func TestSaveLoadOptions(t *testing.T) {
+	testSave := NewHunter()
+	testSave.Options = Options{Salvo: SalvoShips, Source: HeatSample, Samples: 50, CheckLimit: -1, Rand: rand.New(rand.NewSource(1))}
+	testSave.Refresh()
+	testSave.Seek()
+	playMoves(t, &testSave, []string{"E5 Miss"})
+	var buffer bytes.Buffer
+	testSave.Save(&buffer)
+
+	testLoad, err := Load(&buffer)
+	if err != nil {
+		t.Errorf("Load returned an unexpected error with options: %v", err)
+	}
+
+	expected := testSave.Options
+	expected.Rand = nil
+	if testLoad.Options != expected {
+		t.Errorf("Load did not restore the options %+v, got %+v", expected, testLoad.Options)
+	}
+}
+
+var badSaves = []string{
+	`not a saved game`,
+	`{"version": 99, "moves": []}`,
+	`{"version": 1, "rules": {"name": "Wide", "width": 60, "height": 10}, "moves": []}`,
+	`{"version": 1, "moves": [{"square": "A1", "result": "Carrier"}]}`,
+	`{"version": 1, "turns": 2, "moves": [{"square": "A1", "result": "Miss"}]}`,
+	`{"version": 1, "turns": 1, "ships": ["Carrier"], "moves": [{"square": "A1", "result": "Miss"}]}`,
+}
+
+func TestBadLoad(t *testing.T) {
+	for _, save := range badSaves {
+		if result, err := Load(strings.NewReader(save)); err == nil {
+			t.Errorf("Load did not error as expected with %v, returned %v", save, result.Moves)
+		}
+	}
+}
+
+func TestSaveLoadFile(t *testing.T) {
+	testSave, _ := Replay(exampleMoves)
+	path := filepath.Join(t.TempDir(), "saves", "game.json")
+
+	if err := testSave.SaveFile(path); err != nil {
+		t.Errorf("SaveFile returned an unexpected error: %v", err)
+	}
+
+	testLoad, err := LoadFile(path)
+	if err != nil {
+		t.Errorf("LoadFile returned an unexpected error: %v", err)
+	}
+	sameState(t, testLoad, testSave)
+
+	if _, err := LoadFile(filepath.Join(t.TempDir(), "missing.json")); err == nil {
+		t.Errorf("LoadFile did not error with a missing file")
+	}
+}
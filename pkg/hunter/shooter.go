@@ -0,0 +1,38 @@
+package hunter
+
+import (
+	"errors"
+
+	"github.com/eaglerock1337/gobat/pkg/board"
+)
+
+// Shooter is an interface for any engine that plays the shooting side of
+// Battleship, allowing the Hunter to be compared against other algorithms.
+type Shooter interface {
+	NextShot() (board.Square, error)            // Returns the next square to shoot
+	Record(s board.Square, result string) error // Records the result of a shot
+}
+
+// NextShot returns the best square to shoot according to the Hunter's heat map.
+func (h *Hunter) NextShot() (board.Square, error) {
+	if len(h.Shots) == 0 {
+		if h.SeekMode {
+			h.Seek()
+		} else {
+			h.Destroy()
+		}
+	}
+
+	if len(h.Shots) == 0 {
+		if h.Contradiction != nil {
+			return board.Square{}, *h.Contradiction
+		}
+		return board.Square{}, errors.New("no shots available to play")
+	}
+	return h.Shots[0], nil
+}
+
+// Record processes the result of a shot as a turn of the Hunter.
+func (h *Hunter) Record(s board.Square, result string) error {
+	return h.Turn(s, result)
+}
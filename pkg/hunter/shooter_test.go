@@ -0,0 +1,63 @@
+package hunter
+
+import (
+	"testing"
+
+	"github.com/eaglerock1337/gobat/pkg/board"
+)
+
+var _ Shooter = (*Hunter)(nil)
+
+func TestNextShot(t *testing.T) {
+	testNextShot := NewHunter()
+	shot, err := testNextShot.NextShot()
+
+	if err != nil {
+		t.Errorf("NextShot returned an unexpected error: %v", err)
+	}
+
+	if !testNextShot.InShots(shot) || shot != testNextShot.Shots[0] {
+		t.Errorf("NextShot did not return the best shot %v, got %v", testNextShot.Shots, shot)
+	}
+}
+
+func TestNextShotDestroy(t *testing.T) {
+	testNextShot := NewHunter()
+	square, _ := board.SquareByString("E5")
+	testNextShot.Record(square, "Hit")
+	testNextShot.ClearShots()
+
+	shot, err := testNextShot.NextShot()
+	if err != nil {
+		t.Errorf("NextShot returned an unexpected error: %v", err)
+	}
+
+	if distance := abs(shot.Letter-square.Letter) + abs(shot.Number-square.Number); distance != 1 {
+		t.Errorf("NextShot did not return a square next to the hit at %v, got %v", square.PrintSquare(), shot.PrintSquare())
+	}
+}
+
+func TestRecord(t *testing.T) {
+	testRecord := NewHunter()
+	square, _ := board.SquareByString("B2")
+
+	if err := testRecord.Record(square, "Miss"); err != nil {
+		t.Errorf("Record returned an unexpected error: %v", err)
+	}
+
+	if testRecord.Turns != 1 || !testRecord.Board.IsMiss(square) {
+		t.Errorf("Record did not take a turn with a miss at %v, got %v turns", square.PrintSquare(), testRecord.Turns)
+	}
+
+	if err := testRecord.Record(square, "Invalid"); err == nil {
+		t.Errorf("Record did not error with an invalid result")
+	}
+}
+
+// abs returns the absolute value of an integer.
+func abs(x int) int {
+	if x < 0 {
+		return -x
+	}
+	return x
+}
@@ -0,0 +1,182 @@
+package hunter
+
+import (
+	"github.com/eaglerock1337/gobat/pkg/board"
+)
+
+// Sink is a sunk ship whose exact placement is not yet known, as more than
+// one placement of the ship covers the sinking square with outstanding hits.
+// The squares shared by every candidate are already marked as the ship, and
+// the rest stay in the hit stack until the ambiguity is resolved.
+type Sink struct {
+	Ship       board.Ship    // The ship that was sunk
+	Square     board.Square  // The square the ship was sunk on
+	Candidates []board.Piece // The placements the ship could still have
+}
+
+// certain returns the squares that are part of every candidate placement.
+func (s Sink) certain() []board.Square {
+	if len(s.Candidates) == 0 {
+		return nil
+	}
+
+	var squares []board.Square
+	for _, square := range s.Candidates[0].Coords {
+		shared := true
+		for _, candidate := range s.Candidates[1:] {
+			if !candidate.InSquare(square) {
+				shared = false
+				break
+			}
+		}
+		if shared {
+			squares = append(squares, square)
+		}
+	}
+	return squares
+}
+
+// InSinks checks whether a given Square could belong to a ship with an
+// ambiguous placement and returns a boolean.
+func (h Hunter) InSinks(s board.Square) bool {
+	for _, sink := range h.Sinks {
+		for _, candidate := range sink.Candidates {
+			if candidate.InSquare(s) {
+				return true
+			}
+		}
+	}
+	return false
+}
+
+// openHits returns the hits in the hit stack that cannot belong to a sunk
+// ship, which must be covered by the ships that are still afloat.
+func (h Hunter) openHits() []board.Square {
+	var hits []board.Square
+	for _, hit := range h.HitStack {
+		if !h.InSinks(hit) {
+			hits = append(hits, hit)
+		}
+	}
+	return hits
+}
+
+// claimSquare marks a square as belonging to the given sunk ship, removing
+// it from the hit stack and from the piece data of the ships still afloat.
+func (h *Hunter) claimSquare(s board.Square, sh board.Ship) {
+	h.DelHitStack(s)
+	h.Board.SetString(s, sh.GetType())
+	h.DeleteSquare(s)
+}
+
+// consistentSinks removes every candidate placement that no longer fits the
+// board, either because it covers a square that is no longer an outstanding
+// hit, or because it cannot be combined with a candidate of every other
+// ambiguous sink without overlapping.
+func (h *Hunter) consistentSinks() {
+	for i, sink := range h.Sinks {
+		certain := sink.certain()
+		var candidates []board.Piece
+	Candidate:
+		for _, candidate := range sink.Candidates {
+			for _, square := range candidate.Coords {
+				if !h.InHitStack(square) && !containsSquare(certain, square) {
+					continue Candidate
+				}
+			}
+			candidates = append(candidates, candidate)
+		}
+		h.Sinks[i].Candidates = candidates
+	}
+
+	used := make([][]bool, len(h.Sinks))
+	for i, sink := range h.Sinks {
+		used[i] = make([]bool, len(sink.Candidates))
+	}
+	picks := make([]int, len(h.Sinks))
+
+	var combine func(sink int) bool
+	combine = func(sink int) bool {
+		if sink == len(h.Sinks) {
+			for i, pick := range picks {
+				used[i][pick] = true
+			}
+			return true
+		}
+
+		found := false
+	Candidate:
+		for i, candidate := range h.Sinks[sink].Candidates {
+			for j := 0; j < sink; j++ {
+				if candidate.InPiece(h.Sinks[j].Candidates[picks[j]]) {
+					continue Candidate
+				}
+			}
+			picks[sink] = i
+			if combine(sink + 1) {
+				found = true
+			}
+		}
+		return found
+	}
+
+	// without any consistent combination, leave the candidates as they are
+	// rather than discarding every one of them
+	if !combine(0) {
+		return
+	}
+
+	for i, sink := range h.Sinks {
+		var candidates []board.Piece
+		for j, candidate := range sink.Candidates {
+			if used[i][j] {
+				candidates = append(candidates, candidate)
+			}
+		}
+		h.Sinks[i].Candidates = candidates
+	}
+}
+
+// resolveSinks narrows down the candidates of every ambiguous sink, claims
+// the squares shared by all of a sink's candidates, and places every sunk
+// ship that is left with a single candidate on the board.
+func (h *Hunter) resolveSinks() {
+	for resolved := true; resolved; {
+		resolved = false
+		h.consistentSinks()
+
+		for i := 0; i < len(h.Sinks); i++ {
+			sink := h.Sinks[i]
+			if len(sink.Candidates) == 0 {
+				continue
+			}
+
+			for _, square := range sink.certain() {
+				if h.InHitStack(square) {
+					h.claimSquare(square, sink.Ship)
+				}
+			}
+
+			if len(sink.Candidates) == 1 {
+				h.Board.SetPiece(sink.Candidates[0])
+				// no other ship can be placed touching the sunk ship
+				if h.Rules.NoTouching {
+					h.DeleteSquares(h.Board.Around(sink.Candidates[0]))
+				}
+				h.Sinks = append(h.Sinks[:i], h.Sinks[i+1:]...)
+				resolved = true
+				i--
+			}
+		}
+	}
+}
+
+// containsSquare returns whether the given square is in the list of squares.
+func containsSquare(list []board.Square, s board.Square) bool {
+	for _, square := range list {
+		if square == s {
+			return true
+		}
+	}
+	return false
+}
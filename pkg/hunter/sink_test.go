@@ -0,0 +1,86 @@
+package hunter
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/eaglerock1337/gobat/pkg/board"
+)
+
+func TestSearchPieces(t *testing.T) {
+	testSearch := NewHunter()
+	for _, coords := range []string{"C4", "C5", "C6"} {
+		square, _ := board.SquareByString(coords)
+		testSearch.AddHitStack(square)
+	}
+	middle, _ := board.SquareByString("C5")
+
+	pieces := testSearch.SearchPieces(middle, board.Ship("Cruiser"))
+	if len(pieces) != 1 || pieces[0].Coords[0] != (board.Square{Letter: 2, Number: 3}) {
+		t.Errorf("SearchPieces did not find the Cruiser around its middle square, got %v", pieces)
+	}
+
+	if pieces := testSearch.SearchPieces(middle, board.Ship("Destroyer")); len(pieces) != 2 {
+		t.Errorf("SearchPieces did not find both Destroyer placements, got %v", pieces)
+	}
+
+	if pieces := testSearch.SearchPieces(middle, board.Ship("Battleship")); len(pieces) != 0 {
+		t.Errorf("SearchPieces found a Battleship that does not fit the hits, got %v", pieces)
+	}
+}
+
+func playMoves(t *testing.T, h *Hunter, moves []string) {
+	for _, move := range moves {
+		coords, result, _ := strings.Cut(move, " ")
+		square, _ := board.SquareByString(coords)
+		if err := h.Turn(square, result); err != nil {
+			t.Errorf("Turn returned an unexpected error for %v: %v", move, err)
+		}
+	}
+}
+
+func TestAmbiguousSink(t *testing.T) {
+	testSink := NewHunter()
+	playMoves(t, &testSink, []string{"C4 Hit", "C6 Hit", "C5 Destroyer"})
+
+	if len(testSink.Sinks) != 1 || len(testSink.Sinks[0].Candidates) != 2 {
+		t.Errorf("Turn did not keep both Destroyer placements as candidates, got %v", testSink.Sinks)
+	}
+
+	if len(testSink.HitStack) != 2 || testSink.SeekMode {
+		t.Errorf("Turn did not keep hunting the ambiguous hits, got %v", testSink.HitStack)
+	}
+
+	middle, _ := board.SquareByString("C5")
+	if !testSink.Board.IsShip(middle, board.Ship("Destroyer")) || testSink.InHitStack(middle) {
+		t.Errorf("Turn did not claim the square shared by every candidate for the Destroyer")
+	}
+
+	if len(testSink.Ships) != 4 {
+		t.Errorf("Turn did not delete the sunk Destroyer, got %v", testSink.Ships)
+	}
+
+	playMoves(t, &testSink, []string{"C7 Hit", "C8 Cruiser"})
+
+	if len(testSink.Sinks) != 0 || len(testSink.HitStack) != 0 || !testSink.SeekMode {
+		t.Errorf("Turn did not resolve the Destroyer after sinking the Cruiser, got %v", testSink.Sinks)
+	}
+
+	expected := map[string]string{"C4": "Destroyer", "C5": "Destroyer", "C6": "Cruiser", "C7": "Cruiser", "C8": "Cruiser"}
+	for coords, ship := range expected {
+		square, _ := board.SquareByString(coords)
+		if got := testSink.Board.GetString(square); got != ship {
+			t.Errorf("Turn did not resolve square %v to %v, got %v", coords, ship, got)
+		}
+	}
+}
+
+func TestOpenHits(t *testing.T) {
+	testOpen := NewHunter()
+	playMoves(t, &testOpen, []string{"C4 Hit", "C6 Hit", "C5 Destroyer", "H8 Hit"})
+
+	hits := testOpen.openHits()
+	if len(hits) != 1 || hits[0].PrintSquare() != "H8" {
+		t.Errorf("openHits did not leave out the hits of the ambiguous Destroyer, got %v", hits)
+	}
+}
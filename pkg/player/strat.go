@@ -1 +1,399 @@
+/*
+Package player implements the opponent side of a game of Battleship, starting with
+how a player places their fleet on the board. Placements are built entirely from
+board.NewPiece and board.PlacePiece, so every fleet returned is guaranteed to be
+legal: every ship of the fleet in bounds with no overlapping squares, and no ships
+touching when the rules keep them apart.
+
+Every placement function takes a random source, so that simulations and self-play
+can be reproduced exactly by reusing the same seed, and the board.Ruleset whose
+fleet is placed. A ruleset that passes board.Ruleset.Validate can always be placed,
+but on a crowded board a ship placed early can leave no room for the ships after
+it. A placement that gets stuck starts over, and after maxAttempts tries falls
+back to searchFleet, which backtracks through every placement in a random order
+and only returns an error if the fleet cannot be placed at all.
+
+The PlacementStrategy interface allows for testing the hunter against different
+styles of ship placement. Besides placing ships purely at random, strategies are
+provided for hugging the edges of the board, filling the corners, clustering ships
+together, spreading ships as far apart as possible, and hiding ships in the coldest
+squares of a fresh hunter.HeatMap. Each strategy places one ship at a time, scoring
+every legal placement left on the board and choosing randomly among the best ones.
+*/
 package player
+
+import (
+	"errors"
+	"math/rand"
+	"strings"
+
+	"github.com/eaglerock1337/gobat/pkg/board"
+	"github.com/eaglerock1337/gobat/pkg/hunter"
+)
+
+const (
+	maxAttempts    = 20     // The most times a fleet is started over before searching
+	maxRandomTries = 100    // The most random pieces tried for one ship before searching its placements
+	maxSearchSteps = 200000 // The most pieces searchFleet places before giving up
+)
+
+// errNoPlacement is returned when no legal placement of the fleet was found.
+var errNoPlacement = errors.New("no legal placement found for the fleet")
+
+// PlacementStrategy is an interface for any method of placing a full fleet.
+type PlacementStrategy interface {
+	Name() string                                                     // The short name of the strategy
+	Place(rng *rand.Rand, rules board.Ruleset) ([]board.Piece, error) // Returns every ship of the fleet placed legally
+}
+
+// scoreFunc scores a legal piece placement given the ships already placed,
+// where placements with a higher score are preferred.
+type scoreFunc func(placed board.Board, piece board.Piece) int
+
+// Random places every ship uniformly at random.
+type Random struct{}
+
+// Edges places ships along the edges of the board wherever possible.
+type Edges struct{}
+
+// Corners places ships as close to the corners of the board as possible.
+type Corners struct{}
+
+// Clustered places ships touching as many already placed ships as possible.
+type Clustered struct{}
+
+// Spread places ships as far away from already placed ships as possible.
+type Spread struct{}
+
+// AntiHeatmap places ships in the coldest squares of a fresh hunter.HeatMap.
+type AntiHeatmap struct{}
+
+// RandomPiece returns a random in-bounds placement for the given ship.
+func RandomPiece(rng *rand.Rand, rules board.Ruleset, ship board.Ship) board.Piece {
+	length := rules.Length(ship)
+	horizontal := rng.Intn(2) == 0
+
+	// a ship only fits one way on a board narrower or shorter than it
+	if length > rules.Width {
+		horizontal = false
+	} else if length > rules.Height {
+		horizontal = true
+	}
+
+	var let, num int
+	if horizontal {
+		num, let = rng.Intn(rules.Height), rng.Intn(rules.Width-length+1)
+	} else {
+		let, num = rng.Intn(rules.Width), rng.Intn(rules.Height-length+1)
+	}
+
+	piece, _ := rules.NewPiece(ship, board.Square{Letter: let, Number: num}, horizontal)
+	return piece
+}
+
+// RandomFleet places every ship of the fleet at random on the board without
+// any overlap, and returns the list of pieces.
+func RandomFleet(rng *rand.Rand, rules board.Ruleset) ([]board.Piece, error) {
+	for attempt := 0; attempt < maxAttempts; attempt++ {
+		if fleet, ok := randomAttempt(rng, rules); ok {
+			return fleet, nil
+		}
+	}
+	return searchFleet(rng, rules)
+}
+
+// randomAttempt tries to place every ship of the fleet at random. A ship that
+// misses maxRandomTries times is placed at random among its legal placements
+// instead, giving up on the fleet if there are none left.
+func randomAttempt(rng *rand.Rand, rules board.Ruleset) ([]board.Piece, bool) {
+	placed := rules.NewBoard()
+	ships := rules.Ships()
+	fleet := make([]board.Piece, 0, len(ships))
+
+	for _, ship := range ships {
+		piece, ok := randomOpenPiece(rng, rules, placed, ship)
+		if !ok {
+			return nil, false
+		}
+		placed.PlacePiece(piece)
+		fleet = append(fleet, piece)
+	}
+
+	return fleet, true
+}
+
+// randomOpenPiece returns a random legal placement of the ship on the board.
+func randomOpenPiece(rng *rand.Rand, rules board.Ruleset, placed board.Board, ship board.Ship) (board.Piece, bool) {
+	for try := 0; try < maxRandomTries; try++ {
+		piece := RandomPiece(rng, rules, ship)
+		if isOpen(placed, rules, piece) {
+			return piece, true
+		}
+	}
+
+	pieces := Placements(placed, rules, ship)
+	if len(pieces) == 0 {
+		return board.Piece{}, false
+	}
+	return pieces[rng.Intn(len(pieces))], true
+}
+
+// searchFleet places the fleet by backtracking through every placement of each
+// ship in a random order. Ships of the same type are only placed in the order
+// of their placements, so no fleet is tried twice with two ships swapped. It
+// returns an error if every fleet has been tried, or maxSearchSteps pieces have
+// been placed, without placing the whole fleet.
+func searchFleet(rng *rand.Rand, rules board.Ruleset) ([]board.Piece, error) {
+	ships := rules.Ships()
+	fleet := make([]board.Piece, 0, len(ships))
+	placed := rules.NewBoard()
+	squares := placed.Squares()
+	order := rng.Perm(len(squares) * 2)
+	steps := 0
+
+	var place func(from int) bool
+	place = func(from int) bool {
+		if len(fleet) == len(ships) {
+			return true
+		}
+		ship := len(fleet)
+		if ship == 0 || ships[ship] != ships[ship-1] {
+			from = 0
+		}
+
+		for i := from; i < len(order); i++ {
+			if steps == maxSearchSteps {
+				return false
+			}
+			horizontal := order[i]%2 == 0
+			piece, err := rules.NewPiece(ships[ship], squares[order[i]/2], horizontal)
+			if err != nil || (!horizontal && len(piece.Coords) == 1) || !isOpen(placed, rules, piece) {
+				continue
+			}
+			steps++
+
+			placed.PlacePiece(piece)
+			fleet = append(fleet, piece)
+			if place(i + 1) {
+				return true
+			}
+			fleet = fleet[:len(fleet)-1]
+			for _, square := range piece.Coords {
+				placed.SetString(square, "Empty")
+			}
+		}
+		return false
+	}
+
+	if !place(0) {
+		return nil, errNoPlacement
+	}
+	return fleet, nil
+}
+
+// Strategies returns every available placement strategy.
+func Strategies() []PlacementStrategy {
+	return []PlacementStrategy{Random{}, Edges{}, Corners{}, Clustered{}, Spread{}, AntiHeatmap{}}
+}
+
+// StrategyByName returns the placement strategy with the given name.
+func StrategyByName(name string) (PlacementStrategy, error) {
+	for _, strategy := range Strategies() {
+		if strings.EqualFold(strategy.Name(), name) {
+			return strategy, nil
+		}
+	}
+	return nil, errors.New("placement strategy not found")
+}
+
+// Placements returns every legal placement of the given ship that does not
+// overlap (or touch, if the rules say so) any ship already placed on the board.
+func Placements(placed board.Board, rules board.Ruleset, ship board.Ship) []board.Piece {
+	var pieces []board.Piece
+	for _, horizontal := range [2]bool{true, false} {
+		// a ship of length one is the same piece in both directions
+		if !horizontal && rules.Length(ship) == 1 {
+			continue
+		}
+		for _, square := range placed.Squares() {
+			piece, err := rules.NewPiece(ship, square, horizontal)
+			if err != nil || !isOpen(placed, rules, piece) {
+				continue
+			}
+			pieces = append(pieces, piece)
+		}
+	}
+	return pieces
+}
+
+// isOpen returns whether every square of the piece is empty on the board,
+// along with every square around it if the rules keep ships from touching.
+func isOpen(placed board.Board, rules board.Ruleset, piece board.Piece) bool {
+	squares := piece.Coords
+	if rules.NoTouching {
+		squares = append(placed.Around(piece), squares...)
+	}
+	for _, square := range squares {
+		if !placed.IsEmpty(square) {
+			return false
+		}
+	}
+	return true
+}
+
+// placeBest places each ship in turn, choosing at random between the legal
+// placements with the highest score.
+func placeBest(rng *rand.Rand, rules board.Ruleset, score scoreFunc) ([]board.Piece, error) {
+	for attempt := 0; attempt < maxAttempts; attempt++ {
+		if fleet, ok := bestAttempt(rng, rules, score); ok {
+			return fleet, nil
+		}
+	}
+	return searchFleet(rng, rules)
+}
+
+// bestAttempt tries to place every ship of the fleet by score, giving up on
+// the fleet if a ship has no legal placement left.
+func bestAttempt(rng *rand.Rand, rules board.Ruleset, score scoreFunc) ([]board.Piece, bool) {
+	placed := rules.NewBoard()
+	ships := rules.Ships()
+	fleet := make([]board.Piece, 0, len(ships))
+
+	for _, ship := range ships {
+		var best []board.Piece
+		bestScore := 0
+		for _, piece := range Placements(placed, rules, ship) {
+			pieceScore := score(placed, piece)
+			if len(best) == 0 || pieceScore > bestScore {
+				best = best[:0]
+				bestScore = pieceScore
+			}
+			if pieceScore == bestScore {
+				best = append(best, piece)
+			}
+		}
+		if len(best) == 0 {
+			return nil, false
+		}
+
+		piece := best[rng.Intn(len(best))]
+		placed.PlacePiece(piece)
+		fleet = append(fleet, piece)
+	}
+
+	return fleet, true
+}
+
+// distance returns the Manhattan distance between two squares.
+func distance(a, b board.Square) int {
+	return abs(a.Letter-b.Letter) + abs(a.Number-b.Number)
+}
+
+// abs returns the absolute value of an integer.
+func abs(x int) int {
+	if x < 0 {
+		return -x
+	}
+	return x
+}
+
+// Name returns the name of the Random strategy.
+func (Random) Name() string { return "random" }
+
+// Place places the fleet with RandomFleet.
+func (Random) Place(rng *rand.Rand, rules board.Ruleset) ([]board.Piece, error) {
+	return RandomFleet(rng, rules)
+}
+
+// Name returns the name of the Edges strategy.
+func (Edges) Name() string { return "edges" }
+
+// Place places the fleet preferring pieces with the most squares on an edge.
+func (Edges) Place(rng *rand.Rand, rules board.Ruleset) ([]board.Piece, error) {
+	return placeBest(rng, rules, func(placed board.Board, piece board.Piece) int {
+		score := 0
+		for _, square := range piece.Coords {
+			if square.Letter == 0 || square.Letter == rules.Width-1 || square.Number == 0 || square.Number == rules.Height-1 {
+				score++
+			}
+		}
+		return score
+	})
+}
+
+// Name returns the name of the Corners strategy.
+func (Corners) Name() string { return "corners" }
+
+// Place places the fleet preferring pieces closest to any corner.
+func (Corners) Place(rng *rand.Rand, rules board.Ruleset) ([]board.Piece, error) {
+	right, bottom := rules.Width-1, rules.Height-1
+	corners := [4]board.Square{{Letter: 0, Number: 0}, {Letter: 0, Number: bottom}, {Letter: right, Number: 0}, {Letter: right, Number: bottom}}
+	return placeBest(rng, rules, func(placed board.Board, piece board.Piece) int {
+		closest := rules.Width + rules.Height
+		for _, square := range piece.Coords {
+			for _, corner := range corners {
+				if distance(square, corner) < closest {
+					closest = distance(square, corner)
+				}
+			}
+		}
+		return -closest
+	})
+}
+
+// Name returns the name of the Clustered strategy.
+func (Clustered) Name() string { return "clustered" }
+
+// Place places the fleet preferring pieces with the most squares touching
+// another ship.
+func (Clustered) Place(rng *rand.Rand, rules board.Ruleset) ([]board.Piece, error) {
+	return placeBest(rng, rules, func(placed board.Board, piece board.Piece) int {
+		score := 0
+		for _, square := range piece.Coords {
+			for _, step := range [4][2]int{{0, -1}, {0, 1}, {-1, 0}, {1, 0}} {
+				adjacent, err := placed.Square(square.Letter+step[0], square.Number+step[1])
+				if err == nil && !placed.IsEmpty(adjacent) {
+					score++
+				}
+			}
+		}
+		return score
+	})
+}
+
+// Name returns the name of the Spread strategy.
+func (Spread) Name() string { return "spread" }
+
+// Place places the fleet preferring pieces furthest from any placed ship.
+func (Spread) Place(rng *rand.Rand, rules board.Ruleset) ([]board.Piece, error) {
+	return placeBest(rng, rules, func(placed board.Board, piece board.Piece) int {
+		closest := rules.Width + rules.Height
+		for _, other := range placed.Squares() {
+			if placed.IsEmpty(other) {
+				continue
+			}
+			for _, square := range piece.Coords {
+				if distance(square, other) < closest {
+					closest = distance(square, other)
+				}
+			}
+		}
+		return closest
+	})
+}
+
+// Name returns the name of the AntiHeatmap strategy.
+func (AntiHeatmap) Name() string { return "antiheatmap" }
+
+// Place places the fleet preferring pieces with the least total heat in the
+// HeatMap of a new Hunter.
+func (AntiHeatmap) Place(rng *rand.Rand, rules board.Ruleset) ([]board.Piece, error) {
+	heat := hunter.NewHunterWithRules(rules).HeatMap
+	return placeBest(rng, rules, func(placed board.Board, piece board.Piece) int {
+		score := 0
+		for _, square := range piece.Coords {
+			score -= heat.GetSquare(square)
+		}
+		return score
+	})
+}
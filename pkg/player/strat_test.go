@@ -0,0 +1,274 @@
+package player
+
+import (
+	"math/rand"
+	"strings"
+	"testing"
+
+	"github.com/eaglerock1337/gobat/pkg/board"
+	"github.com/eaglerock1337/gobat/pkg/hunter"
+)
+
+func TestRandomPiece(t *testing.T) {
+	rng := rand.New(rand.NewSource(1))
+	horizontals := 0
+
+	for i := 0; i < 1000; i++ {
+		for _, ship := range board.ShipTypes() {
+			piece := RandomPiece(rng, board.DefaultRuleset(), ship)
+
+			if len(piece.Coords) != ship.GetLength() {
+				t.Errorf("RandomPiece returned %v with %v squares, want %v", ship, len(piece.Coords), ship.GetLength())
+			}
+			if piece.Coords[0].Number == piece.Coords[1].Number {
+				horizontals++
+			}
+		}
+	}
+
+	if horizontals < 2000 || horizontals > 3000 {
+		t.Errorf("RandomPiece did not place ships evenly in both directions, got %v of 5000 horizontal", horizontals)
+	}
+}
+
+func TestRandomFleet(t *testing.T) {
+	for seed := int64(0); seed < 100; seed++ {
+		fleet, err := RandomFleet(rand.New(rand.NewSource(seed)), board.DefaultRuleset())
+		if err != nil {
+			t.Errorf("RandomFleet did not place the fleet with seed %v, got %v", seed, err)
+		}
+		if len(fleet) != 5 {
+			t.Errorf("RandomFleet did not place all 5 ships, got %v", fleet)
+		}
+
+		placed := board.NewBoard(10, 10)
+		for i, piece := range fleet {
+			if piece.Type != board.ShipTypes()[i] {
+				t.Errorf("RandomFleet returned ship %v out of order, want %v", piece.Type, board.ShipTypes()[i])
+			}
+			if err := placed.PlacePiece(piece); err != nil {
+				t.Errorf("RandomFleet placed %v overlapping another ship with seed %v", piece.Type, seed)
+			}
+		}
+	}
+}
+
+func TestRandomFleetSeed(t *testing.T) {
+	fleet, _ := RandomFleet(rand.New(rand.NewSource(42)), board.DefaultRuleset())
+	again, _ := RandomFleet(rand.New(rand.NewSource(42)), board.DefaultRuleset())
+
+	for i, piece := range fleet {
+		for j, square := range piece.Coords {
+			if square != again[i].Coords[j] {
+				t.Errorf("RandomFleet was not reproducible for seed 42, got %v and %v", piece, again[i])
+			}
+		}
+	}
+}
+
+func TestStrategies(t *testing.T) {
+	for _, rules := range board.Rulesets() {
+		for _, strategy := range Strategies() {
+			for seed := int64(0); seed < 20; seed++ {
+				fleet, err := strategy.Place(rand.New(rand.NewSource(seed)), rules)
+				if err != nil {
+					t.Errorf("Strategy %v did not place the fleet under %v, got %v", strategy.Name(), rules.Name, err)
+				}
+
+				if len(fleet) != len(rules.Ships()) {
+					t.Errorf("Strategy %v did not place all %v ships under %v, got %v", strategy.Name(), len(rules.Ships()), rules.Name, fleet)
+				}
+
+				placed := rules.NewBoard()
+				for _, piece := range fleet {
+					if len(piece.Coords) != rules.Length(piece.Type) {
+						t.Errorf("Strategy %v placed %v with %v squares under %v", strategy.Name(), piece.Type, len(piece.Coords), rules.Name)
+					}
+					if err := placed.PlacePiece(piece); err != nil {
+						t.Errorf("Strategy %v placed %v overlapping another ship with seed %v", strategy.Name(), piece.Type, seed)
+					}
+				}
+			}
+		}
+	}
+}
+
+func TestStrategiesBoardSize(t *testing.T) {
+	for _, size := range [][2]int{{8, 8}, {15, 15}, {12, 6}} {
+		rules := board.DefaultRuleset().WithSize(size[0], size[1])
+		for _, strategy := range Strategies() {
+			fleet, err := strategy.Place(rand.New(rand.NewSource(1)), rules)
+			if err != nil {
+				t.Errorf("Strategy %v did not place the fleet on the %v board, got %v", strategy.Name(), rules.Size(), err)
+			}
+
+			placed := rules.NewBoard()
+			for _, piece := range fleet {
+				if err := placed.PlacePiece(piece); err != nil {
+					t.Errorf("Strategy %v did not place %v on the %v board: %v", strategy.Name(), piece.Type, rules.Size(), err)
+				}
+			}
+			if len(fleet) != 5 {
+				t.Errorf("Strategy %v did not place all 5 ships on the %v board, got %v", strategy.Name(), rules.Size(), fleet)
+			}
+		}
+	}
+}
+
+func TestStrategiesCrowded(t *testing.T) {
+	russian, _ := board.RulesetByName("Russian")
+	rules := russian.WithSize(7, 7)
+	for _, strategy := range Strategies() {
+		for seed := int64(0); seed < 5; seed++ {
+			fleet, err := strategy.Place(rand.New(rand.NewSource(seed)), rules)
+			if err != nil {
+				t.Errorf("Strategy %v did not place the Russian fleet on a 7x7 board with seed %v: %v", strategy.Name(), seed, err)
+				continue
+			}
+
+			placed := rules.NewBoard()
+			for _, piece := range fleet {
+				if err := placed.PlacePiece(piece); err != nil {
+					t.Errorf("Strategy %v placed %v overlapping another ship with seed %v", strategy.Name(), piece.Type, seed)
+				}
+			}
+		}
+	}
+
+	tiny := russian.WithSize(4, 4)
+	for _, strategy := range Strategies() {
+		if fleet, err := strategy.Place(rand.New(rand.NewSource(1)), tiny); err == nil {
+			t.Errorf("Strategy %v did not error as expected for a fleet that cannot be placed, returned %v", strategy.Name(), fleet)
+		}
+	}
+}
+
+func TestStrategyByName(t *testing.T) {
+	for _, name := range []string{"random", "Edges", "CORNERS", "clustered", "spread", "antiheatmap"} {
+		strategy, err := StrategyByName(name)
+		if err != nil {
+			t.Errorf("StrategyByName returned an unexpected error for %v: %v", name, err)
+		} else if !strings.EqualFold(strategy.Name(), name) {
+			t.Errorf("StrategyByName returned %v for %v", strategy.Name(), name)
+		}
+	}
+
+	if strategy, err := StrategyByName("sneaky"); err == nil {
+		t.Errorf("StrategyByName did not error as expected, returned %v", strategy)
+	}
+}
+
+func TestPlacements(t *testing.T) {
+	placed := board.NewBoard(10, 10)
+	rules := board.DefaultRuleset()
+	carrier := board.Ship("Carrier")
+
+	if len(Placements(placed, rules, carrier)) != 120 {
+		t.Errorf("Placements did not return 120 Carrier placements on an empty board, got %v", len(Placements(placed, rules, carrier)))
+	}
+
+	russian, _ := board.RulesetByName("Russian")
+	if len(Placements(placed, russian, board.Ship("Submarine"))) != 100 {
+		t.Errorf("Placements did not return 100 single square Submarine placements, got %v", len(Placements(placed, russian, board.Ship("Submarine"))))
+	}
+
+	square, _ := board.SquareByString("E5")
+	placed.SetString(square, "Destroyer")
+	for _, piece := range Placements(placed, rules, carrier) {
+		if piece.InSquare(square) {
+			t.Errorf("Placements returned piece %v overlapping a placed ship", piece)
+		}
+	}
+}
+
+// countSquares counts the squares of a fleet that satisfy the given test.
+func countSquares(fleet []board.Piece, test func(board.Square) bool) int {
+	count := 0
+	for _, piece := range fleet {
+		for _, square := range piece.Coords {
+			if test(square) {
+				count++
+			}
+		}
+	}
+	return count
+}
+
+func TestEdges(t *testing.T) {
+	fleet, _ := Edges{}.Place(rand.New(rand.NewSource(3)), board.DefaultRuleset())
+	edge := countSquares(fleet, func(s board.Square) bool {
+		return s.Letter == 0 || s.Letter == 9 || s.Number == 0 || s.Number == 9
+	})
+
+	if edge != 17 {
+		t.Errorf("Edges did not place every ship on an edge, got %v of 17 squares", edge)
+	}
+}
+
+func TestCorners(t *testing.T) {
+	fleet, _ := Corners{}.Place(rand.New(rand.NewSource(3)), board.DefaultRuleset())
+	corners := countSquares(fleet, func(s board.Square) bool {
+		return (s.Letter == 0 || s.Letter == 9) && (s.Number == 0 || s.Number == 9)
+	})
+
+	if corners != 4 {
+		t.Errorf("Corners did not fill all 4 corners, got %v", corners)
+	}
+}
+
+func TestClustered(t *testing.T) {
+	fleet, _ := Clustered{}.Place(rand.New(rand.NewSource(3)), board.DefaultRuleset())
+
+	for i, piece := range fleet[1:] {
+		touching := false
+		for _, other := range fleet[:i+1] {
+			for _, square := range piece.Coords {
+				for _, otherSquare := range other.Coords {
+					if distance(square, otherSquare) == 1 {
+						touching = true
+					}
+				}
+			}
+		}
+		if !touching {
+			t.Errorf("Clustered placed %v without touching another ship: %v", piece.Type, fleet)
+		}
+	}
+}
+
+func TestSpread(t *testing.T) {
+	fleet, _ := Spread{}.Place(rand.New(rand.NewSource(3)), board.DefaultRuleset())
+
+	for i, piece := range fleet {
+		for _, other := range fleet[i+1:] {
+			for _, square := range piece.Coords {
+				for _, otherSquare := range other.Coords {
+					if distance(square, otherSquare) < 2 {
+						t.Errorf("Spread placed %v touching %v: %v", piece.Type, other.Type, fleet)
+					}
+				}
+			}
+		}
+	}
+}
+
+func TestAntiHeatmap(t *testing.T) {
+	heat := hunter.NewHunter().HeatMap
+	fleetHeat := func(fleet []board.Piece) int {
+		total := 0
+		for _, piece := range fleet {
+			for _, square := range piece.Coords {
+				total += heat.GetSquare(square)
+			}
+		}
+		return total
+	}
+
+	coldFleet, _ := AntiHeatmap{}.Place(rand.New(rand.NewSource(3)), board.DefaultRuleset())
+	randomFleet, _ := Random{}.Place(rand.New(rand.NewSource(3)), board.DefaultRuleset())
+	cold, random := fleetHeat(coldFleet), fleetHeat(randomFleet)
+
+	if cold >= random {
+		t.Errorf("AntiHeatmap placed a fleet with %v total heat, not colder than a random fleet with %v", cold, random)
+	}
+}
@@ -0,0 +1,394 @@
+/*
+Package record implements a plain-text game record format for Battleship, modeled
+after the PGN format used for chess. A record is the shared interchange format for
+games played in the simulator and the terminal application, as well as for saved
+games that can be resumed later.
+
+A record starts with a header of tags, one per line, giving details such as the
+rules, date and players of the game. The header is followed by a blank line and the
+numbered list of moves, one per line, each with the square shot and its result:
+
+	[Rules "Milton Bradley 1967"]
+	[Date "2026.10.18"]
+	[Hunter "gobat"]
+	[Opponent "Alex"]
+
+	1. E5 Miss
+	2. B7 Hit
+	3. B8 Cruiser
+
+Under salvo rules, every shot fired in a turn is written on the same numbered line,
+such as "4. C5 Miss F2 Hit H9 Miss".
+
+Comments start with a semicolon and run to the end of the line. Results are the same
+strings that hunter.Hunter.Turn accepts (Miss, Hit, the name of the sunk ship, or
+Sunk when the rules do not name it), and are matched without regard to case. A
+record can be replayed into a new Hunter to restore the game it describes. Games
+played on a board other than 10x10 also have a Size tag, such as [Size "12x12"], and
+their squares continue past J and 10 (e.g. L12 or AA3).
+
+The Rules tag names one of the built-in board rulesets, unless the game was played
+under rules of its own, such as a JSON ruleset. Those records also have a Fleet tag
+listing each ship with its length and count, and AnnounceShip and NoTouching tags:
+
+	[Rules "Small Fleet"]
+	[Fleet "Battleship 4x1, Destroyer 2x2"]
+	[AnnounceShip "true"]
+	[NoTouching "false"]
+*/
+package record
+
+import (
+	"bufio"
+	"errors"
+	"fmt"
+	"io"
+	"os"
+	"path/filepath"
+	"reflect"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/eaglerock1337/gobat/pkg/board"
+	"github.com/eaglerock1337/gobat/pkg/hunter"
+)
+
+// DefaultRules is the name of the ruleset used by records without a Rules tag.
+const DefaultRules = "Milton Bradley 1967"
+
+// Tag is a single named value in the header of a record.
+type Tag struct {
+	Name  string
+	Value string
+}
+
+// Record is a struct holding a full game record: its header and moves.
+type Record struct {
+	Tags  []Tag         // The header tags in the order they are written
+	Moves []hunter.Move // The moves of the game in the order they were played
+}
+
+// New creates an empty record with the Rules and Date tags set.
+func New() Record {
+	var rec Record
+	rec.SetTag("Rules", DefaultRules)
+	rec.SetTag("Date", time.Now().Format("2006.01.02"))
+	return rec
+}
+
+// FromHunter creates a new record holding every move the Hunter has played
+// under the Hunter's rules.
+func FromHunter(h hunter.Hunter) Record {
+	rec := New()
+	if h.Rules.Name != "" {
+		rec.SetRules(h.Rules)
+	}
+	rec.Moves = append(rec.Moves, h.Moves...)
+	return rec
+}
+
+// Tag returns the value of the named tag, and whether it was found.
+func (r Record) Tag(name string) (string, bool) {
+	for _, tag := range r.Tags {
+		if tag.Name == name {
+			return tag.Value, true
+		}
+	}
+	return "", false
+}
+
+// SetTag sets the value of the named tag, adding it to the end of the header
+// if it is not already present.
+func (r *Record) SetTag(name, value string) {
+	for i, tag := range r.Tags {
+		if tag.Name == name {
+			r.Tags[i].Value = value
+			return
+		}
+	}
+	r.Tags = append(r.Tags, Tag{name, value})
+}
+
+// SetRules sets the Rules tag to the name of the given rules, along with the
+// Size tag if the rules are not played on a 10x10 board. Rules that are not
+// one of the built-in rulesets also get the Fleet, AnnounceShip and NoTouching
+// tags, so that the record can be replayed without them.
+func (r *Record) SetRules(rules board.Ruleset) {
+	r.SetTag("Rules", rules.Name)
+	if rules.Width != board.DefaultSize || rules.Height != board.DefaultSize {
+		r.SetTag("Size", rules.Size())
+	}
+
+	builtin, err := board.RulesetByName(rules.Name)
+	if err == nil && reflect.DeepEqual(builtin.WithSize(rules.Width, rules.Height), rules) {
+		return
+	}
+	r.SetTag("Fleet", formatFleet(rules.Fleet))
+	r.SetTag("AnnounceShip", strconv.FormatBool(rules.AnnounceShip))
+	r.SetTag("NoTouching", strconv.FormatBool(rules.NoTouching))
+}
+
+// formatFleet writes a fleet as each ship with its length and count, e.g.
+// Battleship 4x1, Destroyer 2x2.
+func formatFleet(fleet []board.FleetShip) string {
+	entries := make([]string, len(fleet))
+	for i, entry := range fleet {
+		entries[i] = fmt.Sprintf("%s %dx%d", entry.Ship.GetType(), entry.Length, entry.Count)
+	}
+	return strings.Join(entries, ", ")
+}
+
+// parseFleet reads a fleet written by formatFleet.
+func parseFleet(text string) ([]board.FleetShip, error) {
+	var fleet []board.FleetShip
+	for _, entry := range strings.Split(text, ",") {
+		var ship string
+		var length, count int
+		if n, err := fmt.Sscanf(strings.TrimSpace(entry), "%s %dx%d", &ship, &length, &count); err != nil || n != 3 {
+			return nil, fmt.Errorf("fleet ship %q is not written as a ship, length and count, e.g. Destroyer 2x2", strings.TrimSpace(entry))
+		}
+		fleet = append(fleet, board.FleetShip{Ship: board.Ship(ship), Length: length, Count: count})
+	}
+	return fleet, nil
+}
+
+// Write writes the record in the plain-text record format.
+func (r Record) Write(w io.Writer) error {
+	buffer := bufio.NewWriter(w)
+
+	for _, tag := range r.Tags {
+		fmt.Fprintf(buffer, "[%s %s]\n", tag.Name, strconv.Quote(tag.Value))
+	}
+	if len(r.Tags) > 0 {
+		fmt.Fprintln(buffer)
+	}
+	for i, turn := range hunter.Turns(r.Moves) {
+		fmt.Fprintf(buffer, "%d.", i+1)
+		for _, move := range turn {
+			fmt.Fprintf(buffer, " %s %s", move.Square.PrintSquare(), move.Result)
+		}
+		fmt.Fprintln(buffer)
+	}
+
+	return buffer.Flush()
+}
+
+// Parse reads a record in the plain-text record format, returning an error
+// with the offending line number if the record is malformed.
+func Parse(r io.Reader) (Record, error) {
+	var rec Record
+	scanner := bufio.NewScanner(r)
+	line := 0
+
+	for scanner.Scan() {
+		line++
+		text := scanner.Text()
+		if comment := strings.Index(text, ";"); comment >= 0 {
+			text = text[:comment]
+		}
+		text = strings.TrimSpace(text)
+
+		var err error
+		switch {
+		case text == "":
+			continue
+		case strings.HasPrefix(text, "["):
+			if len(rec.Moves) > 0 {
+				err = errors.New("header tag found after the moves")
+			} else {
+				err = rec.parseTag(text)
+			}
+		default:
+			err = rec.parseMove(text)
+		}
+
+		if err != nil {
+			return Record{}, fmt.Errorf("Parse failed on line %d: %v", line, err)
+		}
+	}
+
+	if err := scanner.Err(); err != nil {
+		return Record{}, fmt.Errorf("Parse failed to read the record: %v", err)
+	}
+	return rec, nil
+}
+
+// parseTag parses a single header tag line, e.g. [Rules "Milton Bradley 1967"].
+func (r *Record) parseTag(text string) error {
+	if !strings.HasSuffix(text, "]") {
+		return errors.New("header tag is missing its closing bracket")
+	}
+
+	fields := strings.SplitN(text[1:len(text)-1], " ", 2)
+	if len(fields) != 2 || fields[0] == "" {
+		return errors.New("header tag must have a name and a value")
+	}
+
+	value, err := strconv.Unquote(strings.TrimSpace(fields[1]))
+	if err != nil {
+		return fmt.Errorf("header tag %s has an improperly quoted value", fields[0])
+	}
+
+	if _, found := r.Tag(fields[0]); found {
+		return fmt.Errorf("header tag %s is repeated", fields[0])
+	}
+	r.SetTag(fields[0], value)
+	return nil
+}
+
+// parseMove parses a single numbered move line, e.g. 12. B7 Hit. Under
+// salvo rules, the line holds every shot of the turn, e.g. 12. B7 Hit C3 Miss.
+func (r *Record) parseMove(text string) error {
+	fields := strings.Fields(text)
+	if len(fields) < 3 || len(fields)%2 != 1 || !strings.HasSuffix(fields[0], ".") {
+		return errors.New("move must be a number followed by squares and results")
+	}
+
+	number, err := strconv.Atoi(strings.TrimSuffix(fields[0], "."))
+	if err != nil || number != len(hunter.Turns(r.Moves))+1 {
+		return fmt.Errorf("move number %s is out of sequence", fields[0])
+	}
+
+	for i := 1; i < len(fields); i += 2 {
+		square, err := board.SquareByString(fields[i])
+		if err != nil {
+			return fmt.Errorf("move %d has an invalid square: %v", number, err)
+		}
+
+		result, err := ParseResult(fields[i+1])
+		if err != nil {
+			return fmt.Errorf("move %d has an invalid result: %v", number, err)
+		}
+
+		r.Moves = append(r.Moves, hunter.Move{Square: square, Result: result, Salvo: i > 1})
+	}
+	return nil
+}
+
+// ParseResult returns the result string accepted by hunter.Hunter.Turn for
+// the given result, matching Miss, Hit, Sunk, or a ship type without regard
+// to case.
+func ParseResult(text string) (string, error) {
+	results := []string{"Miss", "Hit", "Sunk"}
+	for _, ship := range board.ShipTypes() {
+		results = append(results, ship.GetType())
+	}
+
+	for _, result := range results {
+		if strings.EqualFold(result, text) {
+			return result, nil
+		}
+	}
+	return "", fmt.Errorf("%s is not Miss, Hit, Sunk, or a ship type", text)
+}
+
+// Rules returns the built-in ruleset named by the Rules tag, or the default
+// rules if the record has no Rules tag, resized to the Size tag if it has one.
+// A record with a Fleet tag has rules of its own, read from the Fleet,
+// AnnounceShip and NoTouching tags instead.
+func (r Record) Rules() (board.Ruleset, error) {
+	name, found := r.Tag("Rules")
+	if !found {
+		name = DefaultRules
+	}
+
+	var rules board.Ruleset
+	var err error
+	if fleet, found := r.Tag("Fleet"); found {
+		rules, err = r.customRules(name, fleet)
+	} else {
+		rules, err = board.RulesetByName(name)
+	}
+	if err != nil {
+		return board.Ruleset{}, err
+	}
+
+	if size, found := r.Tag("Size"); found {
+		width, height, err := board.ParseSize(size)
+		if err != nil {
+			return board.Ruleset{}, err
+		}
+		rules = rules.WithSize(width, height)
+	}
+	return rules, rules.Validate()
+}
+
+// customRules returns the rules of a record with a Fleet tag, played on a
+// 10x10 board unless the record has a Size tag.
+func (r Record) customRules(name, fleet string) (board.Ruleset, error) {
+	rules := board.Ruleset{Name: name, Width: board.DefaultSize, Height: board.DefaultSize}
+
+	var err error
+	if rules.Fleet, err = parseFleet(fleet); err != nil {
+		return board.Ruleset{}, err
+	}
+	for tag, flag := range map[string]*bool{"AnnounceShip": &rules.AnnounceShip, "NoTouching": &rules.NoTouching} {
+		value, found := r.Tag(tag)
+		if !found {
+			continue
+		}
+		if *flag, err = strconv.ParseBool(value); err != nil {
+			return board.Ruleset{}, fmt.Errorf("header tag %s must be true or false, got %q", tag, value)
+		}
+	}
+	return rules, nil
+}
+
+// Replay feeds every move of the record into a new Hunter for the record's
+// rules with Turn, and returns the Hunter with the game restored.
+func (r Record) Replay() (hunter.Hunter, error) {
+	rules, err := r.Rules()
+	if err != nil {
+		return hunter.Hunter{}, fmt.Errorf("Replay failed due to unsupported rules: %v", err)
+	}
+
+	hunt := hunter.NewHunterWithRules(rules)
+	hunt.Seek()
+	for i, turn := range hunter.Turns(r.Moves) {
+		var err error
+		if len(turn) == 1 {
+			err = hunt.Turn(turn[0].Square, turn[0].Result)
+		} else {
+			err = hunt.TurnSalvo(turn)
+		}
+		if err != nil {
+			return hunter.Hunter{}, fmt.Errorf("Replay failed on move %d. %s %s: %v", i+1, turn[0].Square.PrintSquare(), turn[0].Result, err)
+		}
+	}
+	return hunt, nil
+}
+
+// WriteFile writes the record to the given file, creating any missing
+// directories along the way. An error closing the file is returned too, as
+// the record may not have been fully written.
+func (r Record) WriteFile(path string) error {
+	if err := os.MkdirAll(filepath.Dir(path), 0755); err != nil {
+		return fmt.Errorf("WriteFile failed to create the directory: %v", err)
+	}
+
+	file, err := os.Create(path)
+	if err != nil {
+		return fmt.Errorf("WriteFile failed to create the file: %v", err)
+	}
+
+	if err := r.Write(file); err != nil {
+		file.Close()
+		return err
+	}
+	if err := file.Close(); err != nil {
+		return fmt.Errorf("WriteFile failed to close the file: %v", err)
+	}
+	return nil
+}
+
+// ParseFile reads a record from the given file.
+func ParseFile(path string) (Record, error) {
+	file, err := os.Open(path)
+	if err != nil {
+		return Record{}, fmt.Errorf("ParseFile failed to open the file: %v", err)
+	}
+	defer file.Close()
+
+	return Parse(file)
+}
@@ -0,0 +1,349 @@
+package record
+
+import (
+	"bytes"
+	"path/filepath"
+	"reflect"
+	"strings"
+	"testing"
+
+	"github.com/eaglerock1337/gobat/pkg/board"
+	"github.com/eaglerock1337/gobat/pkg/hunter"
+)
+
+var exampleRecord = `[Rules "Milton Bradley 1967"]
+[Date "2026.10.18"]
+[Hunter "gobat"]
+[Opponent "Alex \"The Admiral\""]
+
+1. E5 Miss
+2. C4 Hit
+3. C5 Hit
+4. C6 Cruiser
+5. H8 Miss
+`
+
+var exampleMoves = []hunter.Move{
+	{Square: board.Square{Letter: 4, Number: 4}, Result: "Miss"},
+	{Square: board.Square{Letter: 2, Number: 3}, Result: "Hit"},
+	{Square: board.Square{Letter: 2, Number: 4}, Result: "Hit"},
+	{Square: board.Square{Letter: 2, Number: 5}, Result: "Cruiser"},
+	{Square: board.Square{Letter: 7, Number: 7}, Result: "Miss"},
+}
+
+func TestNew(t *testing.T) {
+	rec := New()
+
+	if rules, _ := rec.Tag("Rules"); rules != DefaultRules {
+		t.Errorf("New did not set the Rules tag to %v, got %v", DefaultRules, rules)
+	}
+	if _, found := rec.Tag("Date"); !found {
+		t.Errorf("New did not set the Date tag: %v", rec.Tags)
+	}
+}
+
+func TestTags(t *testing.T) {
+	var rec Record
+	rec.SetTag("Hunter", "gobat")
+	rec.SetTag("Opponent", "Alex")
+	rec.SetTag("Hunter", "Sam")
+
+	if len(rec.Tags) != 2 || rec.Tags[0].Value != "Sam" {
+		t.Errorf("SetTag did not replace the existing Hunter tag in place: %v", rec.Tags)
+	}
+
+	if value, found := rec.Tag("Referee"); found {
+		t.Errorf("Tag found a tag that was never set: %v", value)
+	}
+}
+
+func TestParse(t *testing.T) {
+	rec, err := Parse(strings.NewReader(exampleRecord))
+	if err != nil {
+		t.Errorf("Parse returned an unexpected error: %v", err)
+	}
+
+	if len(rec.Tags) != 4 {
+		t.Errorf("Parse did not return 4 tags, got %v", rec.Tags)
+	}
+	if opponent, _ := rec.Tag("Opponent"); opponent != `Alex "The Admiral"` {
+		t.Errorf("Parse did not unquote the Opponent tag, got %v", opponent)
+	}
+
+	if len(rec.Moves) != len(exampleMoves) {
+		t.Errorf("Parse did not return %v moves, got %v", len(exampleMoves), rec.Moves)
+	}
+	for i, move := range rec.Moves {
+		if move != exampleMoves[i] {
+			t.Errorf("Parse returned move %v as %v, want %v", i+1, move, exampleMoves[i])
+		}
+	}
+}
+
+func TestParseLenient(t *testing.T) {
+	lenient := "; a friendly game\n\n1. e5 miss ; opening shot\n  2.  c4   HIT\n3. C5 hit\n4. c6 cruiser\n"
+	rec, err := Parse(strings.NewReader(lenient))
+
+	if err != nil {
+		t.Errorf("Parse returned an unexpected error: %v", err)
+	}
+	for i, move := range rec.Moves {
+		if move != exampleMoves[i] {
+			t.Errorf("Parse returned move %v as %v, want %v", i+1, move, exampleMoves[i])
+		}
+	}
+}
+
+var badRecords = []string{
+	"[Rules \"Milton Bradley 1967\"\n",
+	"[Rules Milton Bradley]\n",
+	"[Rules]\n",
+	"[Date \"2026.10.18\"]\n[Date \"2026.10.19\"]\n",
+	"1. A1 Miss\n[Date \"2026.10.18\"]\n",
+	"1. A1 Miss\n3. A2 Miss\n",
+	"1 A1 Miss\n",
+	"1. K0 Miss\n",
+	"1. A1 Sploosh\n",
+	"1. A1\n",
+}
+
+func TestBadParse(t *testing.T) {
+	for _, input := range badRecords {
+		if rec, err := Parse(strings.NewReader(input)); err == nil {
+			t.Errorf("Parse did not error as expected with %q, returned %v", input, rec)
+		}
+	}
+}
+
+func TestWrite(t *testing.T) {
+	rec, _ := Parse(strings.NewReader(exampleRecord))
+	var buffer bytes.Buffer
+
+	if err := rec.Write(&buffer); err != nil {
+		t.Errorf("Write returned an unexpected error: %v", err)
+	}
+	if buffer.String() != exampleRecord {
+		t.Errorf("Write did not reproduce the parsed record, got:\n%v\nwant:\n%v", buffer.String(), exampleRecord)
+	}
+}
+
+func TestParseResult(t *testing.T) {
+	results := map[string]string{"miss": "Miss", "HIT": "Hit", "carrier": "Carrier", "SubMarine": "Submarine"}
+
+	for input, expected := range results {
+		if result, err := ParseResult(input); err != nil || result != expected {
+			t.Errorf("ParseResult was incorrect for %v, got: %v, want: %v", input, result, expected)
+		}
+	}
+
+	if result, err := ParseResult("Kaboom"); err == nil {
+		t.Errorf("ParseResult did not error as expected, returned %v", result)
+	}
+}
+
+func TestReplay(t *testing.T) {
+	rec, _ := Parse(strings.NewReader(exampleRecord))
+	hunt, err := rec.Replay()
+
+	if err != nil {
+		t.Errorf("Replay returned an unexpected error: %v", err)
+	}
+	if hunt.Turns != 5 || len(hunt.Ships) != 4 || !hunt.SeekMode {
+		t.Errorf("Replay did not restore the game, got %v turns and ships %v", hunt.Turns, hunt.Ships)
+	}
+
+	rec.SetTag("Rules", "Salvo")
+	if _, err := rec.Replay(); err == nil {
+		t.Errorf("Replay did not error with unsupported rules")
+	}
+
+	bad := Record{Moves: []hunter.Move{exampleMoves[0], {Square: board.Square{Letter: 0, Number: 0}, Result: "Carrier"}}}
+	if _, err := bad.Replay(); err == nil || !strings.Contains(err.Error(), "move 2") {
+		t.Errorf("Replay did not error on the second move as expected: %v", err)
+	}
+}
+
+var exampleRussianRecord = `[Rules "Russian"]
+
+1. A1 Sunk
+2. C1 Hit
+3. C2 Sunk
+`
+
+func TestReplayRules(t *testing.T) {
+	rec, _ := Parse(strings.NewReader(exampleRussianRecord))
+	hunt, err := rec.Replay()
+
+	if err != nil {
+		t.Errorf("Replay returned an unexpected error with Russian rules: %v", err)
+	}
+	if hunt.Rules.Name != "Russian" || len(hunt.Ships) != 8 {
+		t.Errorf("Replay did not restore the game under Russian rules, got %v with ships %v", hunt.Rules.Name, hunt.Ships)
+	}
+
+	for square, ship := range map[string]string{"A1": "Submarine", "C1": "Destroyer", "C2": "Destroyer"} {
+		sq, _ := board.SquareByString(square)
+		if hunt.Board.GetString(sq) != ship {
+			t.Errorf("Replay did not sink the %v on %v, got %v", ship, square, hunt.Board.GetString(sq))
+		}
+	}
+
+	if FromHunter(hunt).Tags[0].Value != "Russian" {
+		t.Errorf("FromHunter did not keep the Russian rules, got %v", FromHunter(hunt).Tags)
+	}
+}
+
+var exampleLargeRecord = `[Rules "Milton Bradley 1967"]
+[Size "12x12"]
+
+1. L12 Miss
+2. AA1 Miss
+`
+
+func TestReplaySize(t *testing.T) {
+	rec, _ := Parse(strings.NewReader(exampleLargeRecord))
+	if hunt, err := rec.Replay(); err == nil {
+		t.Errorf("Replay did not error on a square off the 12x12 board, returned %v", hunt.Moves)
+	}
+
+	rec.Moves = rec.Moves[:1]
+	hunt, err := rec.Replay()
+	if err != nil {
+		t.Errorf("Replay returned an unexpected error on a 12x12 board: %v", err)
+	}
+	if hunt.Board.Width() != 12 || hunt.Board.Height() != 12 || !hunt.Board.IsMiss(rec.Moves[0].Square) {
+		t.Errorf("Replay did not restore the game on a 12x12 board, got %vx%v", hunt.Board.Width(), hunt.Board.Height())
+	}
+
+	if size, _ := FromHunter(hunt).Tag("Size"); size != "12x12" {
+		t.Errorf("FromHunter did not keep the 12x12 board size, got %v", size)
+	}
+	if _, found := FromHunter(hunter.NewHunter()).Tag("Size"); found {
+		t.Errorf("FromHunter added a Size tag for a 10x10 board")
+	}
+
+	rec.SetTag("Size", "60x60")
+	if _, err := rec.Replay(); err == nil {
+		t.Errorf("Replay did not error with a board size that is too large")
+	}
+}
+
+var exampleCustomRules = board.Ruleset{
+	Name:   "Small Fleet",
+	Width:  8,
+	Height: 8,
+	Fleet: []board.FleetShip{
+		{Ship: board.Ship("Battleship"), Length: 4, Count: 1},
+		{Ship: board.Ship("Destroyer"), Length: 2, Count: 2},
+	},
+	AnnounceShip: true,
+	NoTouching:   true,
+}
+
+func TestCustomRules(t *testing.T) {
+	hunt := hunter.NewHunterWithRules(exampleCustomRules)
+	hunt.Seek()
+	for _, move := range []hunter.Move{{Square: board.Square{Letter: 0, Number: 0}, Result: "Miss"}, {Square: board.Square{Letter: 2, Number: 2}, Result: "Hit"}} {
+		if err := hunt.Turn(move.Square, move.Result); err != nil {
+			t.Errorf("Turn returned an unexpected error under custom rules: %v", err)
+		}
+	}
+
+	var buffer bytes.Buffer
+	FromHunter(hunt).Write(&buffer)
+	if !strings.Contains(buffer.String(), `[Fleet "Battleship 4x1, Destroyer 2x2"]`) {
+		t.Errorf("Write did not write the fleet of the custom rules, got:\n%v", buffer.String())
+	}
+
+	rec, err := Parse(&buffer)
+	if err != nil {
+		t.Errorf("Parse returned an unexpected error: %v", err)
+	}
+	replayed, err := rec.Replay()
+	if err != nil {
+		t.Errorf("Replay returned an unexpected error under custom rules: %v", err)
+	}
+	if !reflect.DeepEqual(replayed.Rules, exampleCustomRules) || !replayed.Board.Equal(hunt.Board) {
+		t.Errorf("Replay did not restore the game under the custom rules, got %v", replayed.Rules)
+	}
+
+	if _, found := FromHunter(hunter.NewHunter()).Tag("Fleet"); found {
+		t.Errorf("FromHunter added a Fleet tag for built-in rules")
+	}
+
+	rec.SetTag("Fleet", "Battleship four")
+	if _, err := rec.Replay(); err == nil {
+		t.Errorf("Replay did not error with a malformed Fleet tag")
+	}
+	rec.SetTag("Fleet", "Battleship 4x1, Destroyer 2x2")
+	rec.SetTag("NoTouching", "maybe")
+	if _, err := rec.Replay(); err == nil {
+		t.Errorf("Replay did not error with a NoTouching tag that is not true or false")
+	}
+}
+
+func TestFromHunter(t *testing.T) {
+	hunt, _ := hunter.Replay(exampleMoves)
+	rec := FromHunter(hunt)
+
+	if len(rec.Moves) != len(exampleMoves) {
+		t.Errorf("FromHunter did not copy the %v moves, got %v", len(exampleMoves), rec.Moves)
+	}
+
+	replayed, err := rec.Replay()
+	if err != nil || !replayed.Board.Equal(hunt.Board) || !replayed.HeatMap.Equal(hunt.HeatMap) {
+		t.Errorf("FromHunter did not produce a record that replays the same game: %v", err)
+	}
+}
+
+func TestFiles(t *testing.T) {
+	rec, _ := Parse(strings.NewReader(exampleRecord))
+	path := filepath.Join(t.TempDir(), "games", "example.gbr")
+
+	if err := rec.WriteFile(path); err != nil {
+		t.Errorf("WriteFile returned an unexpected error: %v", err)
+	}
+
+	parsed, err := ParseFile(path)
+	if err != nil {
+		t.Errorf("ParseFile returned an unexpected error: %v", err)
+	}
+	if len(parsed.Moves) != len(rec.Moves) || len(parsed.Tags) != len(rec.Tags) {
+		t.Errorf("ParseFile did not return the written record, got %v", parsed)
+	}
+
+	if _, err := ParseFile(filepath.Join(t.TempDir(), "missing.gbr")); err == nil {
+		t.Errorf("ParseFile did not error with a missing file")
+	}
+}
+
+var exampleSalvoRecord = `1. E5 Miss
+2. C4 Hit C5 Hit C6 Cruiser
+3. H8 Miss A1 Miss
+`
+
+func TestSalvoRecord(t *testing.T) {
+	rec, err := Parse(strings.NewReader(exampleSalvoRecord))
+	if err != nil {
+		t.Errorf("Parse returned an unexpected error: %v", err)
+	}
+
+	if len(rec.Moves) != 6 || rec.Moves[1].Salvo || !rec.Moves[2].Salvo || rec.Moves[4].Salvo {
+		t.Errorf("Parse did not mark the salvo moves as expected: %v", rec.Moves)
+	}
+
+	var buffer bytes.Buffer
+	rec.Write(&buffer)
+	if buffer.String() != exampleSalvoRecord {
+		t.Errorf("Write did not write each salvo on one line, got:\n%v", buffer.String())
+	}
+
+	hunt, err := rec.Replay()
+	if err != nil || hunt.Turns != 3 || len(hunt.Ships) != 4 {
+		t.Errorf("Replay did not play each salvo as one turn, got %v turns: %v", hunt.Turns, err)
+	}
+
+	if _, err := Parse(strings.NewReader("1. E5 Miss C4\n")); err == nil {
+		t.Errorf("Parse did not error with a shot missing its result")
+	}
+}
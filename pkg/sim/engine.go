@@ -0,0 +1,72 @@
+package sim
+
+import (
+	"errors"
+	"math/rand"
+	"strings"
+
+	"github.com/eaglerock1337/gobat/pkg/board"
+	"github.com/eaglerock1337/gobat/pkg/hunter"
+)
+
+// Engine is a named constructor for the Shooter that plays each game.
+type Engine struct {
+	Name string                                                   // The short name of the engine
+	New  func(rng *rand.Rand, rules board.Ruleset) hunter.Shooter // Creates a new Shooter for a game
+}
+
+// Engines returns every available shooting engine, starting with the Hunter.
+func Engines() []Engine {
+	return []Engine{
+		{"hunter", func(_ *rand.Rand, rules board.Ruleset) hunter.Shooter {
+			hunt := newHunter(rules)
+			return &hunt
+		}},
+		{"exact", func(_ *rand.Rand, rules board.Ruleset) hunter.Shooter {
+			hunt := newHunter(rules)
+			hunt.Options.Source = hunter.HeatExact
+			return &hunt
+		}},
+		{"sample", func(rng *rand.Rand, rules board.Ruleset) hunter.Shooter {
+			hunt := newHunter(rules)
+			hunt.Options.Source = hunter.HeatSample
+			hunt.Options.Samples = 2000
+			hunt.Options.Rand = rng
+			return &hunt
+		}},
+		{"entropy", func(rng *rand.Rand, rules board.Ruleset) hunter.Shooter {
+			hunt := newHunter(rules)
+			hunt.Options.Select = hunter.SelectEntropy
+			hunt.Options.Samples = 1000
+			hunt.Options.Rand = rng
+			return &hunt
+		}},
+		{"random", func(rng *rand.Rand, rules board.Ruleset) hunter.Shooter {
+			return hunter.NewRandomShooter(rng, rules)
+		}},
+		{"hunttarget", func(rng *rand.Rand, rules board.Ruleset) hunter.Shooter {
+			return hunter.NewHuntTarget(rng, rules)
+		}},
+		{"parity", func(rng *rand.Rand, rules board.Ruleset) hunter.Shooter {
+			return hunter.NewParityHuntTarget(rng, rules)
+		}},
+	}
+}
+
+// newHunter creates a Hunter for the given rules without the search for an
+// impossible board after each turn, as the Ocean always reports truthfully.
+func newHunter(rules board.Ruleset) hunter.Hunter {
+	hunt := hunter.NewHunterWithRules(rules)
+	hunt.Options.CheckLimit = -1
+	return hunt
+}
+
+// EngineByName returns the shooting engine with the given name.
+func EngineByName(name string) (Engine, error) {
+	for _, engine := range Engines() {
+		if strings.EqualFold(engine.Name, name) {
+			return engine, nil
+		}
+	}
+	return Engine{}, errors.New("shooting engine not found")
+}
@@ -0,0 +1,29 @@
+package sim
+
+import (
+	"math/rand"
+	"testing"
+
+	"github.com/eaglerock1337/gobat/pkg/board"
+)
+
+func TestEngines(t *testing.T) {
+	for _, engine := range Engines() {
+		shooter := engine.New(rand.New(rand.NewSource(1)), board.DefaultRuleset())
+		if _, err := shooter.NextShot(); err != nil {
+			t.Errorf("Engine %v returned a Shooter that could not take a shot: %v", engine.Name, err)
+		}
+	}
+}
+
+func TestEngineByName(t *testing.T) {
+	for _, name := range []string{"hunter", "Exact", "Sample", "Entropy", "Random", "HuntTarget", "parity"} {
+		if _, err := EngineByName(name); err != nil {
+			t.Errorf("EngineByName returned an unexpected error for %v: %v", name, err)
+		}
+	}
+
+	if engine, err := EngineByName("psychic"); err == nil {
+		t.Errorf("EngineByName did not error as expected, returned %v", engine.Name)
+	}
+}
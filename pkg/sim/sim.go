@@ -0,0 +1,143 @@
+/*
+Package sim is a headless simulator for testing the competitiveness of the hunter
+algorithm. It plays complete games of Battleship with a hunter.Hunter against a
+hidden, randomly placed fleet, answering every shot the same way a human opponent
+would: a Miss, a Hit, or the name of the ship that was just sunk. Games are played
+under the standard rules unless another board.Ruleset is given. Any other
+hunter.Shooter can be played in place of the Hunter as a named Engine, so the
+Hunter can be compared against simpler baseline algorithms.
+
+Each game is played from its own random seed, derived from the seed given in the
+Config, so a batch of games can be reproduced exactly regardless of how many
+workers are used to play them. The number of turns each game took to win is then
+collected into a Stats report for comparing changes to the algorithm.
+*/
+package sim
+
+import (
+	"errors"
+	"fmt"
+	"math/rand"
+	"sync"
+
+	"github.com/eaglerock1337/gobat/pkg/board"
+	"github.com/eaglerock1337/gobat/pkg/game"
+	"github.com/eaglerock1337/gobat/pkg/hunter"
+	"github.com/eaglerock1337/gobat/pkg/player"
+)
+
+// Config holds the settings for a batch of simulated games.
+type Config struct {
+	Games   int   // The number of games to play
+	Seed    int64 // The seed used to derive each game's random source
+	Workers int   // The number of games to play concurrently
+
+	Rules     board.Ruleset            // The rules the games are played under (the default if unset)
+	Placement player.PlacementStrategy // How the hidden fleets are placed (random if nil)
+	Engine    Engine                   // The engine shooting at the fleets (the Hunter if unset)
+	KeepMoves bool                     // Whether to keep the moves of every game played
+}
+
+// Game holds the outcome of a single simulated game.
+type Game struct {
+	Seed  int64 // The seed the hidden fleet was placed with
+	Turns int   // The number of turns the Hunter took
+	Won   bool  // Whether the Hunter sank every ship
+	Err   error // The reason the game was abandoned, if it was not won
+
+	Moves []hunter.Move // The moves played, if they were kept
+}
+
+// Play plays a single game of Battleship under the given rules with a new
+// Shooter from the given engine against a fleet placed by the given strategy
+// from the given seed, returning the outcome of the game.
+func Play(seed int64, rules board.Ruleset, placement player.PlacementStrategy, engine Engine, keepMoves bool) Game {
+	outcome := Game{Seed: seed}
+	fleet, err := placement.Place(rand.New(rand.NewSource(seed)), rules)
+	if err != nil {
+		outcome.Err = err
+		return outcome
+	}
+	target, err := game.NewOceanWithRules(rules, fleet)
+	if err != nil {
+		outcome.Err = err
+		return outcome
+	}
+	shooter := engine.New(rand.New(rand.NewSource(^seed)), rules)
+
+	// a game can take no more turns than the board has squares, as every
+	// square can only be shot once
+	maxTurns := rules.Width * rules.Height
+	for !target.Defeated() {
+		if outcome.Turns >= maxTurns {
+			outcome.Err = errors.New("game exceeded the maximum number of turns")
+			break
+		}
+
+		shot, err := shooter.NextShot()
+		if err != nil {
+			outcome.Err = err
+			break
+		}
+
+		result, err := target.Fire(shot)
+		if err != nil {
+			outcome.Err = fmt.Errorf("shooter played an invalid shot: %v", err)
+			break
+		}
+
+		if err := shooter.Record(shot, result); err != nil {
+			outcome.Err = err
+			break
+		}
+		outcome.Turns++
+		if keepMoves {
+			outcome.Moves = append(outcome.Moves, hunter.Move{Square: shot, Result: result})
+		}
+	}
+
+	outcome.Won = target.Defeated()
+	return outcome
+}
+
+// Run plays a batch of games as described by the Config and returns the
+// outcome of every game in the order they were seeded.
+func Run(cfg Config) []Game {
+	games := make([]Game, cfg.Games)
+	rules := cfg.Rules
+	if rules.Name == "" {
+		rules = board.DefaultRuleset()
+	}
+	placement := cfg.Placement
+	if placement == nil {
+		placement = player.Random{}
+	}
+	engine := cfg.Engine
+	if engine.New == nil {
+		engine = Engines()[0]
+	}
+	workers := cfg.Workers
+	if workers < 1 {
+		workers = 1
+	}
+
+	jobs := make(chan int)
+	var wg sync.WaitGroup
+	for w := 0; w < workers; w++ {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			for i := range jobs {
+				games[i] = Play(cfg.Seed+int64(i), rules, placement, engine, cfg.KeepMoves)
+			}
+		}()
+	}
+
+	for i := range games {
+		jobs <- i
+	}
+	close(jobs)
+	wg.Wait()
+
+	return games
+}
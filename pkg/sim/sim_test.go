@@ -0,0 +1,149 @@
+package sim
+
+import (
+	"math/rand"
+	"testing"
+
+	"github.com/eaglerock1337/gobat/pkg/board"
+	"github.com/eaglerock1337/gobat/pkg/hunter"
+	"github.com/eaglerock1337/gobat/pkg/player"
+)
+
+func TestPlay(t *testing.T) {
+	game := Play(3, board.DefaultRuleset(), player.Random{}, Engines()[0], false)
+
+	if !game.Won {
+		t.Errorf("Play did not win the game with seed 3: %v", game.Err)
+	}
+
+	if game.Turns < 17 || game.Turns > 100 {
+		t.Errorf("Play returned an impossible number of turns: %v", game.Turns)
+	}
+
+	again := Play(3, board.DefaultRuleset(), player.Random{}, Engines()[0], false)
+	if again.Turns != game.Turns {
+		t.Errorf("Play was not reproducible for seed 3, got %v and %v turns", game.Turns, again.Turns)
+	}
+}
+
+func TestRun(t *testing.T) {
+	games := Run(Config{Games: 20, Seed: 100, Workers: 4})
+
+	if len(games) != 20 {
+		t.Errorf("Run did not return 20 games, got %v", len(games))
+	}
+
+	for i, game := range games {
+		if game.Seed != 100+int64(i) {
+			t.Errorf("Run returned game %v out of order with seed %v", i, game.Seed)
+		}
+		if single := Play(game.Seed, board.DefaultRuleset(), player.Random{}, Engines()[0], false); single.Turns != game.Turns {
+			t.Errorf("Run game with seed %v took %v turns, but Play took %v", game.Seed, game.Turns, single.Turns)
+		}
+	}
+}
+
+func TestRunPlacement(t *testing.T) {
+	for _, strategy := range player.Strategies() {
+		games := Run(Config{Games: 5, Seed: 1, Workers: 2, Placement: strategy})
+
+		for _, game := range games {
+			if single := Play(game.Seed, board.DefaultRuleset(), strategy, Engines()[0], false); single.Turns != game.Turns {
+				t.Errorf("Run with %v placement took %v turns for seed %v, but Play took %v", strategy.Name(), game.Turns, game.Seed, single.Turns)
+			}
+		}
+	}
+}
+
+func TestRunEngines(t *testing.T) {
+	for _, engine := range Engines() {
+		games := Run(Config{Games: 10, Seed: 5, Workers: 2, Engine: engine})
+
+		for _, game := range games {
+			if !game.Won {
+				t.Errorf("Engine %v did not win the game with seed %v: %v", engine.Name, game.Seed, game.Err)
+			}
+			if single := Play(game.Seed, board.DefaultRuleset(), player.Random{}, engine, false); single.Turns != game.Turns {
+				t.Errorf("Run with engine %v took %v turns for seed %v, but Play took %v", engine.Name, game.Turns, game.Seed, single.Turns)
+			}
+		}
+	}
+}
+
+func TestKeepMoves(t *testing.T) {
+	game := Play(3, board.DefaultRuleset(), player.Random{}, Engines()[0], true)
+
+	if len(game.Moves) != game.Turns {
+		t.Errorf("Play did not keep all %v moves, got %v", game.Turns, len(game.Moves))
+	}
+
+	hunt, err := hunter.Replay(game.Moves)
+	if err != nil || len(hunt.Ships) != 0 {
+		t.Errorf("Play kept moves that do not replay into a won game: %v, ships left %v", err, hunt.Ships)
+	}
+
+	if games := Run(Config{Games: 2, Seed: 3}); games[0].Moves != nil {
+		t.Errorf("Run kept moves without KeepMoves set")
+	}
+}
+
+func TestRunRules(t *testing.T) {
+	for _, rules := range board.Rulesets() {
+		games := Run(Config{Games: 20, Seed: 7, Workers: 2, Rules: rules})
+
+		for _, game := range games {
+			if !game.Won {
+				t.Errorf("Run did not win game %v under %v rules: %v", game.Seed, rules.Name, game.Err)
+			}
+		}
+	}
+}
+
+func TestRunSize(t *testing.T) {
+	for _, size := range [][2]int{{8, 8}, {12, 12}, {15, 15}} {
+		rules := board.DefaultRuleset().WithSize(size[0], size[1])
+		for _, engine := range Engines() {
+			games := Run(Config{Games: 2, Seed: 7, Workers: 2, Rules: rules, Engine: engine})
+
+			for _, game := range games {
+				if !game.Won || game.Turns > size[0]*size[1] {
+					t.Errorf("Run did not win game %v with %v on a %v board in %v turns: %v", game.Seed, engine.Name, rules.Size(), game.Turns, game.Err)
+				}
+			}
+		}
+	}
+}
+
+func TestCheckHeat(t *testing.T) {
+	checked := Engine{"checked", func(_ *rand.Rand, rules board.Ruleset) hunter.Shooter {
+		hunt := hunter.NewHunterWithRules(rules)
+		hunt.Options.CheckHeat = true
+		return &hunt
+	}}
+
+	for _, rules := range append(board.Rulesets(), board.DefaultRuleset().WithSize(12, 8)) {
+		games := Run(Config{Games: 20, Seed: 7, Workers: 2, Rules: rules, Engine: checked})
+
+		for _, game := range games {
+			if !game.Won {
+				t.Errorf("Run did not win game %v under %v rules with the HeatMap checked: %v", game.Seed, rules.Name, game.Err)
+			}
+		}
+	}
+}
+
+// BenchmarkPlay plays one game per iteration with each of the Hunter's
+// engines, so simulator workloads can be measured with e.g. -benchtime 100000x.
+func BenchmarkPlay(b *testing.B) {
+	for _, name := range []string{"hunter", "exact", "sample"} {
+		engine, _ := EngineByName(name)
+		b.Run(name, func(b *testing.B) {
+			for i := 0; i < b.N; i++ {
+				game := Play(int64(i), board.DefaultRuleset(), player.Random{}, engine, false)
+				if !game.Won {
+					b.Fatalf("Play did not win the game with seed %v: %v", i, game.Err)
+				}
+			}
+		})
+	}
+}
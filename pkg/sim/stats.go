@@ -0,0 +1,137 @@
+package sim
+
+import (
+	"fmt"
+	"io"
+	"sort"
+	"strings"
+)
+
+// Stats holds the turns-to-win statistics for a batch of simulated games.
+// Only games that were won are counted in the turn statistics.
+type Stats struct {
+	Games    int   // The total number of games played
+	Failures int   // The number of games that were abandoned
+	Turns    []int // The sorted turns-to-win of every game won
+}
+
+// NewStats collects the statistics for the given games.
+func NewStats(games []Game) Stats {
+	stats := Stats{Games: len(games)}
+	for _, game := range games {
+		if !game.Won {
+			stats.Failures++
+			continue
+		}
+		stats.Turns = append(stats.Turns, game.Turns)
+	}
+	sort.Ints(stats.Turns)
+	return stats
+}
+
+// Mean returns the average number of turns taken to win.
+func (s Stats) Mean() float64 {
+	if len(s.Turns) == 0 {
+		return 0
+	}
+	total := 0
+	for _, turns := range s.Turns {
+		total += turns
+	}
+	return float64(total) / float64(len(s.Turns))
+}
+
+// Median returns the median number of turns taken to win.
+func (s Stats) Median() float64 {
+	length := len(s.Turns)
+	if length == 0 {
+		return 0
+	}
+	if length%2 == 0 {
+		return float64(s.Turns[length/2-1]+s.Turns[length/2]) / 2
+	}
+	return float64(s.Turns[length/2])
+}
+
+// Min returns the fewest turns taken to win.
+func (s Stats) Min() int {
+	if len(s.Turns) == 0 {
+		return 0
+	}
+	return s.Turns[0]
+}
+
+// Max returns the most turns taken to win.
+func (s Stats) Max() int {
+	if len(s.Turns) == 0 {
+		return 0
+	}
+	return s.Turns[len(s.Turns)-1]
+}
+
+// Percentile returns the number of turns within which the given percentage
+// of games were won, using the nearest-rank method.
+func (s Stats) Percentile(p float64) int {
+	length := len(s.Turns)
+	if length == 0 {
+		return 0
+	}
+	rank := int(p/100*float64(length)+0.999999) - 1
+	if rank < 0 {
+		rank = 0
+	}
+	if rank >= length {
+		rank = length - 1
+	}
+	return s.Turns[rank]
+}
+
+// Histogram returns the number of games won in each bucket of turns, where
+// the key of each bucket is the lowest turn count it holds.
+func (s Stats) Histogram(width int) map[int]int {
+	if width < 1 {
+		width = 1
+	}
+	histogram := make(map[int]int)
+	for _, turns := range s.Turns {
+		histogram[turns/width*width]++
+	}
+	return histogram
+}
+
+// Print writes a human-readable report of the statistics.
+func (s Stats) Print(w io.Writer, width int) {
+	fmt.Fprintf(w, "Games played: %d\n", s.Games)
+	fmt.Fprintf(w, "Games won:    %d\n", len(s.Turns))
+	fmt.Fprintf(w, "Failures:     %d\n", s.Failures)
+	if len(s.Turns) == 0 {
+		return
+	}
+
+	fmt.Fprintf(w, "\nTurns to win:\n")
+	fmt.Fprintf(w, "  Mean:   %.2f\n", s.Mean())
+	fmt.Fprintf(w, "  Median: %.1f\n", s.Median())
+	fmt.Fprintf(w, "  Min:    %d\n", s.Min())
+	fmt.Fprintf(w, "  Max:    %d\n", s.Max())
+	for _, p := range []float64{10, 25, 75, 90, 99} {
+		fmt.Fprintf(w, "  P%-5v %d\n", p, s.Percentile(p))
+	}
+
+	histogram := s.Histogram(width)
+	buckets := make([]int, 0, len(histogram))
+	most := 0
+	for bucket, count := range histogram {
+		buckets = append(buckets, bucket)
+		if count > most {
+			most = count
+		}
+	}
+	sort.Ints(buckets)
+
+	fmt.Fprintf(w, "\nHistogram:\n")
+	for _, bucket := range buckets {
+		count := histogram[bucket]
+		bar := strings.Repeat("#", count*50/most)
+		fmt.Fprintf(w, "  %3d-%-3d %6d %s\n", bucket, bucket+width-1, count, bar)
+	}
+}
@@ -0,0 +1,103 @@
+package sim
+
+import (
+	"bytes"
+	"errors"
+	"strings"
+	"testing"
+)
+
+var exampleGames = []Game{
+	{Turns: 50, Won: true},
+	{Turns: 40, Won: true},
+	{Turns: 12, Won: false, Err: errors.New("failed")},
+	{Turns: 60, Won: true},
+	{Turns: 30, Won: true},
+	{Turns: 45, Won: true},
+}
+
+func TestNewStats(t *testing.T) {
+	stats := NewStats(exampleGames)
+
+	if stats.Games != 6 || stats.Failures != 1 {
+		t.Errorf("NewStats did not count 6 games and 1 failure, got %v and %v", stats.Games, stats.Failures)
+	}
+
+	expected := []int{30, 40, 45, 50, 60}
+	for i, turns := range expected {
+		if stats.Turns[i] != turns {
+			t.Errorf("NewStats did not return sorted turns %v, got %v", expected, stats.Turns)
+			break
+		}
+	}
+}
+
+func TestSummary(t *testing.T) {
+	stats := NewStats(exampleGames)
+
+	if stats.Mean() != 45 {
+		t.Errorf("Mean was incorrect, got: %v, want: %v", stats.Mean(), 45)
+	}
+	if stats.Median() != 45 {
+		t.Errorf("Median was incorrect, got: %v, want: %v", stats.Median(), 45)
+	}
+	if stats.Min() != 30 || stats.Max() != 60 {
+		t.Errorf("Min and Max were incorrect, got: %v and %v, want: 30 and 60", stats.Min(), stats.Max())
+	}
+
+	even := Stats{Turns: []int{10, 20, 30, 40}}
+	if even.Median() != 25 {
+		t.Errorf("Median of an even list was incorrect, got: %v, want: %v", even.Median(), 25)
+	}
+
+	var empty Stats
+	if empty.Mean() != 0 || empty.Median() != 0 || empty.Min() != 0 || empty.Max() != 0 || empty.Percentile(50) != 0 {
+		t.Errorf("Stats with no games won did not return zero values")
+	}
+}
+
+var examplePercentiles = map[float64]int{
+	0:   30,
+	20:  30,
+	40:  40,
+	50:  45,
+	90:  60,
+	100: 60,
+}
+
+func TestPercentile(t *testing.T) {
+	stats := NewStats(exampleGames)
+
+	for p, expected := range examplePercentiles {
+		if result := stats.Percentile(p); result != expected {
+			t.Errorf("Percentile %v was incorrect, got: %v, want: %v", p, result, expected)
+		}
+	}
+}
+
+func TestHistogram(t *testing.T) {
+	stats := NewStats(exampleGames)
+	expected := map[int]int{30: 1, 40: 2, 50: 1, 60: 1}
+	histogram := stats.Histogram(10)
+
+	if len(histogram) != len(expected) {
+		t.Errorf("Histogram returned unexpected buckets %v, want %v", histogram, expected)
+	}
+
+	for bucket, count := range expected {
+		if histogram[bucket] != count {
+			t.Errorf("Histogram bucket %v was incorrect, got: %v, want: %v", bucket, histogram[bucket], count)
+		}
+	}
+}
+
+func TestPrint(t *testing.T) {
+	var output bytes.Buffer
+	NewStats(exampleGames).Print(&output, 10)
+
+	for _, line := range []string{"Games played: 6", "Failures:     1", "Mean:   45.00", "40-49"} {
+		if !strings.Contains(output.String(), line) {
+			t.Errorf("Print did not include %q in its report:\n%v", line, output.String())
+		}
+	}
+}